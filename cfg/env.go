@@ -1,22 +1,116 @@
 package cfg
 
 import (
+	"fmt"
 	goenv "github.com/Netflix/go-env"
 	"github.com/joho/godotenv"
 	"github.com/jorgejr568/freecurrencyapi-go/v2"
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type EnvironmentVariables struct {
-	DATABASE_URL             string        `env:"DATABASE_URL,required=true"`
+	DATABASE_URL             string        `env:"DATABASE_URL"`
 	HTTP_PORT                string        `env:"HTTP_PORT,default=8080"`
 	EXCHANGE_RATE_API_URL    string        `env:"EXCHANGE_RATE_API_URL"`
 	EXCHANGE_SYNC_SLEEP      time.Duration `env:"EXCHANGE_SYNC_SLEEP,default=30m"`
 	EXCHANGE_CURRENCIES_FROM string        `env:"EXCHANGE_CURRENCIES_FROM,default=USD;EUR;GBP;JPY"`
 	EXCHANGE_CURRENCIES_TO   string        `env:"EXCHANGE_CURRENCIES_TO,default=BRL"`
-	FREE_CURRENCY_API_KEY    string        `env:"FREE_CURRENCY_API_KEY,required=true"`
+	FREE_CURRENCY_API_KEY    string        `env:"FREE_CURRENCY_API_KEY"`
+
+	// FREE_CURRENCY_API_URL overrides the freecurrencyapi base URL, like
+	// http://localhost:9090/v1/ to use the fake-provider command.
+	FREE_CURRENCY_API_URL string `env:"FREE_CURRENCY_API_URL"`
+
+	// EXCHANGE_PROVIDERS lists the exchange rate providers to sync from, in order of
+	// preference. A provider that fails or times out is skipped for EXCHANGE_PROVIDER_COOLDOWN.
+	EXCHANGE_PROVIDERS         string        `env:"EXCHANGE_PROVIDERS,default=freecurrencyapi"`
+	EXCHANGE_PROVIDER_TIMEOUT  time.Duration `env:"EXCHANGE_PROVIDER_TIMEOUT,default=10s"`
+	EXCHANGE_PROVIDER_COOLDOWN time.Duration `env:"EXCHANGE_PROVIDER_COOLDOWN,default=5m"`
+
+	// EXCHANGE_RATE_API_URL is the base URL of the http provider, to which
+	// EXCHANGE_HTTP_PROVIDER_PATH and EXCHANGE_HTTP_PROVIDER_HISTORICAL_PATH are appended
+	// with {from}, {to} and {date} replaced. EXCHANGE_HTTP_PROVIDER_RATE_PATH is the
+	// dot-separated path to the rate in the JSON response, like data.rates.{to}.
+	// EXCHANGE_HTTP_PROVIDER_API_KEY is sent in the EXCHANGE_HTTP_PROVIDER_AUTH_HEADER
+	// header or the EXCHANGE_HTTP_PROVIDER_AUTH_QUERY_PARAM query parameter.
+	EXCHANGE_HTTP_PROVIDER_PATH             string `env:"EXCHANGE_HTTP_PROVIDER_PATH,default=/convert?from={from}&to={to}"`
+	EXCHANGE_HTTP_PROVIDER_HISTORICAL_PATH  string `env:"EXCHANGE_HTTP_PROVIDER_HISTORICAL_PATH,default=/convert?from={from}&to={to}&date={date}"`
+	EXCHANGE_HTTP_PROVIDER_RATE_PATH        string `env:"EXCHANGE_HTTP_PROVIDER_RATE_PATH,default=result"`
+	EXCHANGE_HTTP_PROVIDER_API_KEY          string `env:"EXCHANGE_HTTP_PROVIDER_API_KEY"`
+	EXCHANGE_HTTP_PROVIDER_AUTH_HEADER      string `env:"EXCHANGE_HTTP_PROVIDER_AUTH_HEADER"`
+	EXCHANGE_HTTP_PROVIDER_AUTH_QUERY_PARAM string `env:"EXCHANGE_HTTP_PROVIDER_AUTH_QUERY_PARAM"`
+
+	// EXCHANGE_ECB_DAILY_URL and EXCHANGE_ECB_HISTORICAL_URL are the ECB reference
+	// rate feeds read by the ecb provider, the latter covering the last 90 days.
+	EXCHANGE_ECB_DAILY_URL      string `env:"EXCHANGE_ECB_DAILY_URL,default=https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"`
+	EXCHANGE_ECB_HISTORICAL_URL string `env:"EXCHANGE_ECB_HISTORICAL_URL,default=https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"`
+
+	// EXCHANGE_FILE_PROVIDER_PATH is the YAML, JSON or CSV file of pinned rates
+	// read by the file provider, reloaded whenever it changes.
+	EXCHANGE_FILE_PROVIDER_PATH string `env:"EXCHANGE_FILE_PROVIDER_PATH"`
+
+	// EXCHANGE_SIMULATED_RATES are the rates from EXCHANGE_SIMULATED_ANCHOR the simulated
+	// provider starts at, as currency:rate separated by semicolons. Each then follows a
+	// geometric random walk every EXCHANGE_SIMULATED_STEP, with the annualised
+	// EXCHANGE_SIMULATED_DRIFT and EXCHANGE_SIMULATED_VOLATILITY. The same
+	// EXCHANGE_SIMULATED_SEED and EXCHANGE_SIMULATED_START, an RFC 3339 time defaulting
+	// to startup, always give the same rates.
+	EXCHANGE_SIMULATED_ANCHOR     string        `env:"EXCHANGE_SIMULATED_ANCHOR,default=USD"`
+	EXCHANGE_SIMULATED_RATES      string        `env:"EXCHANGE_SIMULATED_RATES,default=BRL:5;EUR:0.92;GBP:0.79;JPY:150"`
+	EXCHANGE_SIMULATED_DRIFT      float64       `env:"EXCHANGE_SIMULATED_DRIFT,default=0"`
+	EXCHANGE_SIMULATED_VOLATILITY float64       `env:"EXCHANGE_SIMULATED_VOLATILITY,default=0.2"`
+	EXCHANGE_SIMULATED_STEP       time.Duration `env:"EXCHANGE_SIMULATED_STEP,default=1m"`
+	EXCHANGE_SIMULATED_SEED       uint64        `env:"EXCHANGE_SIMULATED_SEED,default=1"`
+	EXCHANGE_SIMULATED_START      string        `env:"EXCHANGE_SIMULATED_START"`
+
+	// EXCHANGE_PROVIDER_RETRY_ATTEMPTS bounds the calls made to a provider for one
+	// rate when it fails transiently, waiting an exponential, jittered backoff
+	// between them. Attempts are bounded by EXCHANGE_PROVIDER_TIMEOUT as a whole.
+	EXCHANGE_PROVIDER_RETRY_ATTEMPTS    int           `env:"EXCHANGE_PROVIDER_RETRY_ATTEMPTS,default=3"`
+	EXCHANGE_PROVIDER_RETRY_BACKOFF     time.Duration `env:"EXCHANGE_PROVIDER_RETRY_BACKOFF,default=500ms"`
+	EXCHANGE_PROVIDER_RETRY_MAX_BACKOFF time.Duration `env:"EXCHANGE_PROVIDER_RETRY_MAX_BACKOFF,default=5s"`
+
+	// EXCHANGE_PROVIDER_BREAKER_FAILURES consecutive failures open a provider's
+	// circuit breaker, short-circuiting its calls for EXCHANGE_PROVIDER_BREAKER_OPEN_TIMEOUT.
+	// It then closes after EXCHANGE_PROVIDER_BREAKER_HALF_OPEN_SUCCESSES successful trial calls.
+	EXCHANGE_PROVIDER_BREAKER_FAILURES            int           `env:"EXCHANGE_PROVIDER_BREAKER_FAILURES,default=5"`
+	EXCHANGE_PROVIDER_BREAKER_OPEN_TIMEOUT        time.Duration `env:"EXCHANGE_PROVIDER_BREAKER_OPEN_TIMEOUT,default=1m"`
+	EXCHANGE_PROVIDER_BREAKER_HALF_OPEN_SUCCESSES int           `env:"EXCHANGE_PROVIDER_BREAKER_HALF_OPEN_SUCCESSES,default=1"`
+
+	// EXCHANGE_PROVIDER_STRATEGY is either failover, using the first provider that
+	// answers, or aggregate, using the median of every provider's quote. Aggregated
+	// quotes more than EXCHANGE_AGGREGATION_MAX_DEVIATION percent off the median are
+	// rejected, and at least EXCHANGE_AGGREGATION_MIN_QUOTES must remain.
+	EXCHANGE_PROVIDER_STRATEGY         string  `env:"EXCHANGE_PROVIDER_STRATEGY,default=failover"`
+	EXCHANGE_AGGREGATION_MAX_DEVIATION float64 `env:"EXCHANGE_AGGREGATION_MAX_DEVIATION,default=2"`
+	EXCHANGE_AGGREGATION_MIN_QUOTES    int     `env:"EXCHANGE_AGGREGATION_MIN_QUOTES,default=1"`
+
+	// EXCHANGE_PROVIDER_RATE_LIMITS sets per-provider quotas as provider:perMinute:perMonth,
+	// separated by semicolons. Zero disables a limit and unlisted providers are unlimited.
+	// Calls are counted in the database, so monthly quotas survive restarts.
+	EXCHANGE_PROVIDER_RATE_LIMITS string `env:"EXCHANGE_PROVIDER_RATE_LIMITS,default=freecurrencyapi:10:5000"`
+
+	// EXCHANGE_SYNC_MODE is either fixed, syncing every EXCHANGE_SYNC_SLEEP, or
+	// budget, spreading the calls left in EXCHANGE_SYNC_MONTHLY_BUDGET over the rest
+	// of the month, no closer than EXCHANGE_SYNC_MIN_INTERVAL.
+	EXCHANGE_SYNC_MODE           string        `env:"EXCHANGE_SYNC_MODE,default=fixed"`
+	EXCHANGE_SYNC_MONTHLY_BUDGET int64         `env:"EXCHANGE_SYNC_MONTHLY_BUDGET,default=0"`
+	EXCHANGE_SYNC_MIN_INTERVAL   time.Duration `env:"EXCHANGE_SYNC_MIN_INTERVAL,default=1m"`
+
+	// EXCHANGE_PIVOT_CURRENCIES are tried in order to convert between currencies
+	// that have no direct or inverse exchange.
+	EXCHANGE_PIVOT_CURRENCIES string `env:"EXCHANGE_PIVOT_CURRENCIES,default=USD;EUR"`
+
+	// EXCHANGE_RATE_JSON_NUMBER makes the API emit rates as JSON numbers instead of
+	// strings, for clients that predate decimal rates. Numbers may lose precision.
+	// Clients override it with an Accept of "application/json; decimals=string"
+	// or "application/json; decimals=number".
+	EXCHANGE_RATE_JSON_NUMBER bool `env:"EXCHANGE_RATE_JSON_NUMBER,default=false"`
 }
 
 var _env *EnvironmentVariables
@@ -48,7 +142,92 @@ func (e *EnvironmentVariables) CurrenciesTo() []string {
 	return strings.Split(e.EXCHANGE_CURRENCIES_TO, ";")
 }
 
-func (e *EnvironmentVariables) FreeCurrencyAPIClient() freecurrencyapi.Client {
-	return freecurrencyapi.NewClient(e.FREE_CURRENCY_API_KEY)
+func (e *EnvironmentVariables) Providers() []string {
+	return strings.Split(e.EXCHANGE_PROVIDERS, ";")
+}
+
+// AggregationMaxDeviation returns EXCHANGE_AGGREGATION_MAX_DEVIATION as a fraction.
+func (e *EnvironmentVariables) AggregationMaxDeviation() decimal.Decimal {
+	return decimal.NewFromFloat(e.EXCHANGE_AGGREGATION_MAX_DEVIATION).Div(decimal.NewFromInt(100))
+}
+
+// ProviderRateLimit is a provider's quota. Zero disables a limit.
+type ProviderRateLimit struct {
+	PerMinute int
+	PerMonth  int64
+}
+
+// ProviderRateLimits parses EXCHANGE_PROVIDER_RATE_LIMITS by provider name.
+func (e *EnvironmentVariables) ProviderRateLimits() (map[string]ProviderRateLimit, error) {
+	limits := map[string]ProviderRateLimit{}
+	for _, entry := range strings.Split(e.EXCHANGE_PROVIDER_RATE_LIMITS, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid EXCHANGE_PROVIDER_RATE_LIMITS entry %q, expected provider:perMinute:perMonth", entry)
+		}
+
+		perMinute, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid per-minute limit in EXCHANGE_PROVIDER_RATE_LIMITS entry %q: %w", entry, err)
+		}
+
+		perMonth, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid monthly limit in EXCHANGE_PROVIDER_RATE_LIMITS entry %q: %w", entry, err)
+		}
+
+		limits[strings.TrimSpace(parts[0])] = ProviderRateLimit{PerMinute: perMinute, PerMonth: perMonth}
+	}
+
+	return limits, nil
+}
+
+// SimulatedRates parses EXCHANGE_SIMULATED_RATES by currency.
+func (e *EnvironmentVariables) SimulatedRates() (map[string]decimal.Decimal, error) {
+	rates := map[string]decimal.Decimal{}
+	for _, entry := range strings.Split(e.EXCHANGE_SIMULATED_RATES, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		currency, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid EXCHANGE_SIMULATED_RATES entry %q, expected currency:rate", entry)
+		}
+
+		rate, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil || !rate.IsPositive() {
+			return nil, fmt.Errorf("invalid rate in EXCHANGE_SIMULATED_RATES entry %q, expected a positive number", entry)
+		}
+
+		rates[strings.TrimSpace(currency)] = rate
+	}
+
+	return rates, nil
+}
+
+// SimulatedStart parses EXCHANGE_SIMULATED_START, which is zero when unset.
+func (e *EnvironmentVariables) SimulatedStart() (time.Time, error) {
+	if e.EXCHANGE_SIMULATED_START == "" {
+		return time.Time{}, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, e.EXCHANGE_SIMULATED_START)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid EXCHANGE_SIMULATED_START %q, expected an RFC 3339 time", e.EXCHANGE_SIMULATED_START)
+	}
+
+	return start, nil
+}
+
+func (e *EnvironmentVariables) PivotCurrencies() []string {
+	return strings.Split(e.EXCHANGE_PIVOT_CURRENCIES, ";")
+}
 
+func (e *EnvironmentVariables) FreeCurrencyAPIClient(httpClient *http.Client) freecurrencyapi.Client {
+	return freecurrencyapi.NewClient(e.FREE_CURRENCY_API_KEY, freecurrencyapi.Options().WithHTTPClient(httpClient).WithBaseURL(e.FREE_CURRENCY_API_URL))
 }
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/cfg"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/use-cases"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Fills past daily exchange rates from the providers' historical rates",
+	Long: `Fills past daily exchange rates from the providers' historical rates. Days a pair
+already has a rate on are skipped, so backfilling a range again records nothing.`,
+	Example: `  exchange-register-go backfill --from 2024-01-01 --to 2024-06-30 --pairs USD:BRL,EUR:BRL`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		requests, err := backfillRequests(cmd)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid backfill flags")
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer cancel()
+
+		db, err := infra.NewDB(ctx, cfg.Env().DATABASE_URL)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create db")
+			return
+		}
+		defer db.Close()
+
+		providers, err := newExchangeRateProviders(exchange.NewKSQLProviderUsageService(db))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to set up exchange rate providers")
+			return
+		}
+
+		runBackfill(ctx, use_cases.NewBackfillExchangeRatesUseCase(exchange.NewKSQLExchangeService(db), providers.client), providers, requests)
+	},
+}
+
+// runBackfill backfills each source currency in turn, refusing up front when
+// every provider has used up its monthly quota.
+func runBackfill(ctx context.Context, useCase entity.BackfillExchangeRatesUseCase, providers *exchangeRateProviders, requests []entity.BackfillExchangeRatesRequest) {
+	if err := providers.limiters.CheckBudget(ctx); err != nil {
+		log.Error().Err(err).Msg("backfill refused")
+		return
+	}
+
+	for _, request := range requests {
+		res, err := useCase.Execute(ctx, request)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to backfill exchange rates from %s", request.SourceCurrency)
+		}
+
+		if res != nil {
+			log.Info().Msgf("backfilled %s to %s: %d rates recorded, %d already recorded", request.SourceCurrency, strings.Join(request.TargetCurrencies, ","), res.Created, res.Skipped)
+		}
+	}
+}
+
+// backfillRequests reads the flags into one request per source currency, in
+// the order pairs name them. Without pairs it backfills the synced ones.
+func backfillRequests(cmd *cobra.Command) ([]entity.BackfillExchangeRatesRequest, error) {
+	from, err := dateFlag(cmd, "from")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := dateFlag(cmd, "to")
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := cmd.Flags().GetStringSlice("pairs")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pairs) == 0 {
+		requests := make([]entity.BackfillExchangeRatesRequest, 0)
+		for _, sync := range syncRequests() {
+			requests = append(requests, entity.BackfillExchangeRatesRequest{
+				SourceCurrency:   sync.SourceCurrency,
+				TargetCurrencies: sync.TargetCurrencies,
+				From:             from,
+				To:               to,
+			})
+		}
+
+		return requests, nil
+	}
+
+	requests := make([]entity.BackfillExchangeRatesRequest, 0, len(pairs))
+	bySource := map[string]int{}
+	for _, pair := range pairs {
+		source, target, ok := strings.Cut(pair, ":")
+		source, target = entity.NormalizeCurrency(source), entity.NormalizeCurrency(target)
+		if !ok || !entity.IsCurrency(source) || !entity.IsCurrency(target) || source == target {
+			return nil, fmt.Errorf("invalid pair %q, expected two different ISO 4217 codes as SOURCE:TARGET", pair)
+		}
+
+		i, ok := bySource[source]
+		if !ok {
+			i = len(requests)
+			bySource[source] = i
+			requests = append(requests, entity.BackfillExchangeRatesRequest{SourceCurrency: source, From: from, To: to})
+		}
+
+		requests[i].TargetCurrencies = append(requests[i].TargetCurrencies, target)
+	}
+
+	return requests, nil
+}
+
+func dateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s %q, expected YYYY-MM-DD", name, value)
+	}
+
+	return date, nil
+}
+
+func init() {
+	backfillCmd.Flags().String("from", "", "first day to backfill, as YYYY-MM-DD")
+	backfillCmd.Flags().String("to", time.Now().UTC().Format(time.DateOnly), "last day to backfill, as YYYY-MM-DD")
+	backfillCmd.Flags().StringSlice("pairs", nil, "pairs to backfill as SOURCE:TARGET, comma separated (default the synced pairs)")
+	_ = backfillCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(backfillCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"github.com/jorgejr568/exchange-register-go/internal/fakeprovider"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+)
+
+var fakeProviderCmd = &cobra.Command{
+	Use:   "fake-provider",
+	Short: "Starts a fake exchange rate provider for local development",
+	Long: `Starts a fake exchange rate provider for local development. It serves the
+simulated provider's rates (EXCHANGE_SIMULATED_*) on freecurrencyapi's /v1/latest
+and /v1/historical, to use with FREE_CURRENCY_API_URL, and on the http provider's
+/convert, to use with EXCHANGE_RATE_API_URL.
+
+Its /admin endpoints inject behaviour into those routes:
+  GET    /admin/state    what is injected and how many provider requests were served
+  PUT    /admin/rates    rates served instead of the simulated ones, as {"USD": {"BRL": 5.1}}
+  DELETE /admin/rates    serves the simulated rates again
+  PUT    /admin/latency  delays every request, as {"latency": "2s"}
+  PUT    /admin/fault    fails requests with a 429 or 5xx, as {"status": 429, "count": 3, "retry_after": "10s"},
+                         every one of them while count is 0
+  DELETE /admin/fault    stops failing requests
+  POST   /admin/reset    clears everything injected and the request count`,
+	Example: `  exchange-register-go fake-provider --port 9090`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := cmd.Flags().GetString("port")
+		if err != nil {
+			log.Error().Err(err).Msg("failed to get port flag")
+			return
+		}
+
+		source, err := newSimulatedProvider()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to set up the simulated rates")
+			return
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer cancel()
+
+		log.Info().Msgf("fake provider running on port %s... (press Ctrl+C to quit)", port)
+		if err := fakeprovider.NewFakeProvider(source, port).GracefulListenAndShutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("fake provider stopped with error")
+		}
+	},
+}
+
+func init() {
+	fakeProviderCmd.Flags().StringP("port", "p", "9090", "http server port")
+	rootCmd.AddCommand(fakeProviderCmd)
+}
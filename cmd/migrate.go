@@ -1,39 +1,135 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/jorgejr568/exchange-register-go/cfg"
 	migrations2 "github.com/jorgejr568/exchange-register-go/internal/exchange/migrations"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"strconv"
+	"text/tabwriter"
+	"time"
 )
 
 // migrateCmd represents the migrate command
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Migrate the database",
-	Long:  `Migrate the database`,
+	Long:  `Migrate the database. Without a subcommand it applies every pending migration.`,
+	Args:  cobra.NoArgs,
+	Run:   migrateUpCmd.Run,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply every pending migration",
+	Long:  `Apply every pending migration`,
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		log.Info().Msg("migrate called")
+		log.Info().Msg("migrate up called")
 
 		ctx := cmd.Context()
-		db, err := infra.NewKsqlPgDB(ctx, cfg.Env().DATABASE_URL)
+		migrator, db := newMigrator(cmd)
+		defer db.Close()
+
+		err := migrator.Up(ctx)
 		if err != nil {
-			log.Fatal().Err(err).Msg("failed to create database connection")
+			log.Fatal().Err(err).Msg("failed to apply migrations")
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Revert the last N applied migrations (default 1)",
+	Long:  `Revert the last N applied migrations (default 1)`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Info().Msg("migrate down called")
+
+		steps := 1
+		if len(args) == 1 {
+			var err error
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				log.Fatal().Msgf("invalid number of migrations to revert: %s", args[0])
+			}
 		}
 
-		err = migrations2.CreateExchangesTable(ctx, db)
+		ctx := cmd.Context()
+		migrator, db := newMigrator(cmd)
+		defer db.Close()
+
+		err := migrator.Down(ctx, steps)
 		if err != nil {
-			log.Fatal().Err(err).Msg("failed to create exchanges table")
+			log.Fatal().Err(err).Msg("failed to revert migrations")
 		}
+	},
+}
 
-		err = migrations2.CreateExchangeRatesTable(ctx, db)
+var migrateToCmd = &cobra.Command{
+	Use:   "to <version>",
+	Short: "Migrate up or down to the given version",
+	Long:  `Migrate up or down to the given version. Version 0 reverts every migration.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Info().Msg("migrate to called")
+
+		version, err := strconv.ParseUint(args[0], 10, 64)
 		if err != nil {
-			log.Fatal().Err(err).Msg("failed to create exchange_rates table")
+			log.Fatal().Msgf("invalid migration version: %s", args[0])
 		}
+
+		ctx := cmd.Context()
+		migrator, db := newMigrator(cmd)
+		defer db.Close()
+
+		err = migrator.To(ctx, version)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed to migrate to version %d", version)
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the applied and pending migrations",
+	Long:  `Show the applied and pending migrations`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		migrator, db := newMigrator(cmd)
+		defer db.Close()
+
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to get migrations status")
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			state, appliedAt := "pending", "-"
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		w.Flush()
 	},
 }
 
+func newMigrator(cmd *cobra.Command) (migrations2.Migrator, infra.DB) {
+	db, err := infra.NewDB(cmd.Context(), cfg.Env().DATABASE_URL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create database connection")
+	}
+
+	return migrations2.NewMigrator(db, migrations2.All()), db
+}
+
 func init() {
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateToCmd, migrateStatusCmd)
 	rootCmd.AddCommand(migrateCmd)
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/cfg"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
+	"net/http"
+)
+
+const (
+	providerFreeCurrencyAPI = "freecurrencyapi"
+	providerHTTP            = "http"
+	providerECB             = "ecb"
+	providerFile            = "file"
+	providerSimulated       = "simulated"
+)
+
+const (
+	strategyFailover  = "failover"
+	strategyAggregate = "aggregate"
+)
+
+// exchangeRateProviders is the client syncs go through, along with the
+// per-provider decorators whose state is reported.
+type exchangeRateProviders struct {
+	client   exchangerate.Client
+	breakers exchangerate.Breakers
+	limiters exchangerate.RateLimiters
+
+	names []string
+	// batches is whether every provider gets all targets of a base in one call.
+	batches bool
+}
+
+// callsPerSync is how many provider calls syncing requests takes. Aggregating
+// queries every provider, while failing over mostly queries the first.
+func (p *exchangeRateProviders) callsPerSync(requests []entity.SyncExchangeRatesRequest) int64 {
+	queried := 1
+	if cfg.Env().EXCHANGE_PROVIDER_STRATEGY == strategyAggregate {
+		queried = len(p.names)
+	}
+
+	return scheduler.CallsPerSync(requests, p.batches, queried)
+}
+
+// newExchangeRateProviders combines the providers in EXCHANGE_PROVIDERS as set
+// by EXCHANGE_PROVIDER_STRATEGY. Each provider keeps within its rate limits,
+// counting calls in usage, retries transient failures and sits behind a circuit breaker.
+func newExchangeRateProviders(usage entity.ProviderUsageService) (*exchangeRateProviders, error) {
+	limits, err := cfg.Env().ProviderRateLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	names := cfg.Env().Providers()
+	providers := make([]exchangerate.Provider, 0, len(names))
+	breakers := make(exchangerate.Breakers, 0, len(names))
+	limiters := make(exchangerate.RateLimiters, 0, len(names))
+	batches := true
+	for _, name := range names {
+		client, err := newProvider(name)
+		if err != nil {
+			return nil, err
+		}
+		batches = batches && exchangerate.Batches(client)
+
+		limiter := exchangerate.NewRateLimitedClient(
+			exchangerate.Provider{Name: name, Client: client},
+			limits[name].PerMinute,
+			limits[name].PerMonth,
+			usage,
+		)
+		limiters = append(limiters, limiter)
+
+		client = exchangerate.NewRetryingClient(
+			limiter,
+			cfg.Env().EXCHANGE_PROVIDER_RETRY_ATTEMPTS,
+			cfg.Env().EXCHANGE_PROVIDER_RETRY_BACKOFF,
+			cfg.Env().EXCHANGE_PROVIDER_RETRY_MAX_BACKOFF,
+		)
+		breaker := exchangerate.NewCircuitBreakerClient(
+			exchangerate.Provider{Name: name, Client: client},
+			cfg.Env().EXCHANGE_PROVIDER_BREAKER_FAILURES,
+			cfg.Env().EXCHANGE_PROVIDER_BREAKER_OPEN_TIMEOUT,
+			cfg.Env().EXCHANGE_PROVIDER_BREAKER_HALF_OPEN_SUCCESSES,
+		)
+		breakers = append(breakers, breaker)
+		providers = append(providers, exchangerate.Provider{Name: name, Client: breaker})
+	}
+
+	client, err := combineProviders(providers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchangeRateProviders{
+		client:   client,
+		breakers: breakers,
+		limiters: limiters,
+		names:    names,
+		batches:  batches,
+	}, nil
+}
+
+func combineProviders(providers []exchangerate.Provider) (exchangerate.Client, error) {
+	switch strategy := cfg.Env().EXCHANGE_PROVIDER_STRATEGY; strategy {
+	case strategyFailover:
+		return exchangerate.NewFailoverClient(
+			providers,
+			cfg.Env().EXCHANGE_PROVIDER_TIMEOUT,
+			cfg.Env().EXCHANGE_PROVIDER_COOLDOWN,
+		), nil
+	case strategyAggregate:
+		return exchangerate.NewAggregatingClient(
+			providers,
+			cfg.Env().EXCHANGE_PROVIDER_TIMEOUT,
+			cfg.Env().AggregationMaxDeviation(),
+			cfg.Env().EXCHANGE_AGGREGATION_MIN_QUOTES,
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange provider strategy %q, expected %s or %s", strategy, strategyFailover, strategyAggregate)
+	}
+}
+
+func newProvider(name string) (exchangerate.Client, error) {
+	switch name {
+	case providerFreeCurrencyAPI:
+		if cfg.Env().FREE_CURRENCY_API_KEY == "" {
+			return nil, fmt.Errorf("provider %s requires FREE_CURRENCY_API_KEY", name)
+		}
+
+		// The SDK doesn't report statuses, which the retries need to tell 429 and 5xx apart.
+		httpClient := &http.Client{Transport: exchangerate.NewStatusTransport(http.DefaultTransport)}
+		return exchangerate.NewFreeCurrencyApiClient(cfg.Env().FreeCurrencyAPIClient(httpClient)), nil
+	case providerHTTP:
+		if cfg.Env().EXCHANGE_RATE_API_URL == "" {
+			return nil, fmt.Errorf("provider %s requires EXCHANGE_RATE_API_URL", name)
+		}
+
+		mapping := exchangerate.HTTPMapping{
+			BaseURL:        cfg.Env().EXCHANGE_RATE_API_URL,
+			Path:           cfg.Env().EXCHANGE_HTTP_PROVIDER_PATH,
+			HistoricalPath: cfg.Env().EXCHANGE_HTTP_PROVIDER_HISTORICAL_PATH,
+			APIKey:         cfg.Env().EXCHANGE_HTTP_PROVIDER_API_KEY,
+			AuthHeader:     cfg.Env().EXCHANGE_HTTP_PROVIDER_AUTH_HEADER,
+			AuthQueryParam: cfg.Env().EXCHANGE_HTTP_PROVIDER_AUTH_QUERY_PARAM,
+			RatePath:       cfg.Env().EXCHANGE_HTTP_PROVIDER_RATE_PATH,
+		}
+
+		if mapping.Path == "" || mapping.RatePath == "" {
+			return nil, fmt.Errorf("provider %s requires EXCHANGE_HTTP_PROVIDER_PATH and EXCHANGE_HTTP_PROVIDER_RATE_PATH", name)
+		}
+
+		if mapping.APIKey != "" && (mapping.AuthHeader == "") == (mapping.AuthQueryParam == "") {
+			return nil, fmt.Errorf("provider %s requires exactly one of EXCHANGE_HTTP_PROVIDER_AUTH_HEADER or EXCHANGE_HTTP_PROVIDER_AUTH_QUERY_PARAM with EXCHANGE_HTTP_PROVIDER_API_KEY", name)
+		}
+
+		return exchangerate.NewMappedHTTPClient(http.DefaultClient, mapping), nil
+	case providerECB:
+		return exchangerate.NewECBClient(http.DefaultClient, cfg.Env().EXCHANGE_ECB_DAILY_URL, cfg.Env().EXCHANGE_ECB_HISTORICAL_URL), nil
+	case providerFile:
+		path := cfg.Env().EXCHANGE_FILE_PROVIDER_PATH
+		if path == "" {
+			return nil, fmt.Errorf("provider %s requires EXCHANGE_FILE_PROVIDER_PATH", name)
+		}
+
+		client, err := exchangerate.NewFileClient(path)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", name, err)
+		}
+
+		return client, nil
+	case providerSimulated:
+		return newSimulatedProvider()
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q, expected %s, %s, %s, %s or %s", name, providerFreeCurrencyAPI, providerHTTP, providerECB, providerFile, providerSimulated)
+	}
+}
+
+func newSimulatedProvider() (exchangerate.Client, error) {
+	rates, err := cfg.Env().SimulatedRates()
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := cfg.Env().SimulatedStart()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Env().EXCHANGE_SIMULATED_ANCHOR == "" || cfg.Env().EXCHANGE_SIMULATED_STEP <= 0 || cfg.Env().EXCHANGE_SIMULATED_VOLATILITY < 0 {
+		return nil, fmt.Errorf("provider %s requires EXCHANGE_SIMULATED_ANCHOR, a positive EXCHANGE_SIMULATED_STEP and a non-negative EXCHANGE_SIMULATED_VOLATILITY", providerSimulated)
+	}
+
+	return exchangerate.NewSimulatedClient(exchangerate.RandomWalk{
+		Seed:       cfg.Env().EXCHANGE_SIMULATED_SEED,
+		Anchor:     cfg.Env().EXCHANGE_SIMULATED_ANCHOR,
+		Rates:      rates,
+		Drift:      cfg.Env().EXCHANGE_SIMULATED_DRIFT,
+		Volatility: cfg.Env().EXCHANGE_SIMULATED_VOLATILITY,
+		Step:       cfg.Env().EXCHANGE_SIMULATED_STEP,
+		Start:      start,
+	}), nil
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"github.com/jorgejr568/exchange-register-go/cfg"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
 	use_cases "github.com/jorgejr568/exchange-register-go/internal/exchange/use-cases"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
 	"github.com/jorgejr568/exchange-register-go/server"
@@ -13,6 +16,11 @@ import (
 	"os/signal"
 )
 
+const (
+	storageDatabase = "database"
+	storageMemory   = "memory"
+)
+
 var serviceCmd = &cobra.Command{
 	Use:   "service",
 	Short: "Starts the http server and the sync process on the background",
@@ -30,6 +38,12 @@ var serviceCmd = &cobra.Command{
 			return
 		}
 
+		storage, err := cmd.Flags().GetString("storage")
+		if err != nil {
+			log.Error().Err(err).Msg("failed to get storage flag")
+			return
+		}
+
 		ctx, cancel := context.WithCancel(cmd.Context())
 		defer cancel()
 
@@ -40,21 +54,34 @@ var serviceCmd = &cobra.Command{
 			cancel()
 		}()
 
-		db, err := infra.NewKsqlPgDB(ctx, cfg.Env().DATABASE_URL)
+		service, usage, closeStorage, err := newExchangeService(ctx, storage)
 		if err != nil {
-			log.Panic().Err(err).Msg("failed to connect to database")
+			log.Panic().Err(err).Msg("failed to set up storage")
 		}
+		defer closeStorage()
 
-		service := exchange.NewKSQLExchangeService(db)
-		listExchangesUseCase := use_cases.NewListExchangesUseCase(service)
-		s := server.NewEchoServer(listExchangesUseCase, port)
+		useCases := server.UseCases{
+			ListExchanges:   use_cases.NewListExchangesUseCase(service),
+			GetExchange:     use_cases.NewGetExchangeUseCase(service),
+			ExchangeHistory: use_cases.NewExchangeHistoryUseCase(service),
+			Convert:         use_cases.NewConvertUseCase(service, cfg.Env().PivotCurrencies()),
+		}
 
 		if syncWorkerEnabled {
-			go syncCmd.Run(cmd, args)
+			providers, schedule, err := newSyncWorker(usage)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to set up the sync worker, sync worker disabled")
+			} else {
+				useCases.ProviderStatus = providers.breakers
+				useCases.SyncSchedule = schedule
+				go runSyncWorker(ctx, service, providers, schedule)
+			}
 		}
 
+		s := server.NewEchoServer(useCases, port, cfg.Env().EXCHANGE_RATE_JSON_NUMBER)
+
 		go func() {
-			log.Info().Msgf("exchange-register-go service running on port %s... (press Ctrl+C to quit)", cfg.Env().HTTP_PORT)
+			log.Info().Msgf("exchange-register-go service running on port %s with %s storage... (press Ctrl+C to quit)", port, storage)
 			<-ctx.Done()
 			log.Info().Msg("exchange-register-go service stopped")
 		}()
@@ -64,8 +91,49 @@ var serviceCmd = &cobra.Command{
 	},
 }
 
+// newSyncWorker builds the providers and scheduler of the sync worker.
+func newSyncWorker(usage entity.ProviderUsageService) (*exchangeRateProviders, scheduler.Scheduler, error) {
+	providers, err := newExchangeRateProviders(usage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule, err := newSyncScheduler(usage, providers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return providers, schedule, nil
+}
+
+// newExchangeService builds the exchange and provider usage services for the
+// given storage along with a function releasing their resources.
+func newExchangeService(ctx context.Context, storage string) (entity.ExchangeService, entity.ProviderUsageService, func(), error) {
+	switch storage {
+	case storageMemory:
+		return exchange.NewInMemoryExchangeService(), exchange.NewInMemoryProviderUsageService(), func() {}, nil
+	case storageDatabase:
+		db, err := infra.NewDB(ctx, cfg.Env().DATABASE_URL)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		closeDB := func() {
+			err := db.Close()
+			if err != nil {
+				log.Error().Err(err).Msg("failed to close db")
+			}
+		}
+
+		return exchange.NewKSQLExchangeService(db), exchange.NewKSQLProviderUsageService(db), closeDB, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown storage %q, expected %q or %q", storage, storageDatabase, storageMemory)
+	}
+}
+
 func init() {
 	serviceCmd.Flags().StringP("port", "p", cfg.Env().HTTP_PORT, "http server port")
 	serviceCmd.Flags().BoolP("sync", "s", false, "sync worker enabled")
+	serviceCmd.Flags().String("storage", storageDatabase, "where exchanges are stored: database (DATABASE_URL) or memory")
 	rootCmd.AddCommand(serviceCmd)
 }
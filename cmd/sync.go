@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"github.com/jorgejr568/exchange-register-go/cfg"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/use-cases"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
 	"github.com/rs/zerolog/log"
@@ -21,7 +23,7 @@ var syncCmd = &cobra.Command{
 	Long:  `Syncs the exchange rates from the external API`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		db, err := infra.NewKsqlPgDB(ctx, cfg.Env().DATABASE_URL)
+		db, err := infra.NewDB(ctx, cfg.Env().DATABASE_URL)
 		if err != nil {
 			log.Error().Err(err).Msg("failed to create db")
 			return
@@ -30,18 +32,22 @@ var syncCmd = &cobra.Command{
 		exchangeService := exchange.NewKSQLExchangeService(
 			db,
 		)
-		exchangeRateClient := exchangerate.NewFreeCurrencyApiClient(
-			cfg.Env().FreeCurrencyAPIClient(),
-		)
+		usage := exchange.NewKSQLProviderUsageService(db)
+		providers, err := newExchangeRateProviders(usage)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to set up exchange rate providers")
+			return
+		}
+
+		schedule, err := newSyncScheduler(usage, providers)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to set up sync scheduler")
+			return
+		}
 
-		useCase := use_cases.NewSyncExchangeRateUseCase(
-			exchangeService,
-			exchangeRateClient,
-		)
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
-		runSync(ctx, useCase)
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, os.Interrupt)
 		go func() {
@@ -50,40 +56,103 @@ var syncCmd = &cobra.Command{
 			cancel()
 		}()
 
-		for {
-			select {
-			case <-ctx.Done():
-				log.Info().Msg("exchange-register-go sync stopped")
-				err := db.Close()
-				if err != nil {
-					log.Error().Err(err).Msg("failed to close db")
-				}
-				return
-			case <-time.After(cfg.Env().EXCHANGE_SYNC_SLEEP):
-				runSync(ctx, useCase)
-			}
+		runSyncWorker(ctx, exchangeService, providers, schedule)
+		err = db.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to close db")
 		}
 	},
 }
 
-func runSync(ctx context.Context, useCase entity.SyncExchangeRateUseCase) {
+// runSyncWorker syncs the configured exchange rates into exchangeService as
+// often as schedule says until ctx is done.
+func runSyncWorker(ctx context.Context, exchangeService entity.ExchangeService, providers *exchangeRateProviders, schedule scheduler.Scheduler) {
+	useCase := use_cases.NewSyncExchangeRatesUseCase(
+		exchangeService,
+		providers.client,
+	)
+
+	for {
+		runSync(ctx, useCase, providers.limiters)
+
+		wait, err := schedule.Schedule(ctx, time.Now())
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to schedule the next sync, retrying in %s", cfg.Env().EXCHANGE_SYNC_SLEEP)
+			wait = cfg.Env().EXCHANGE_SYNC_SLEEP
+		} else {
+			log.Info().Msgf("next sync in %s", wait.Round(time.Second))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("exchange-register-go sync stopped")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// newSyncScheduler schedules syncs as set by EXCHANGE_SYNC_MODE, counting the
+// calls made to providers in usage against EXCHANGE_SYNC_MONTHLY_BUDGET.
+func newSyncScheduler(usage entity.ProviderUsageService, providers *exchangeRateProviders) (scheduler.Scheduler, error) {
+	budget := cfg.Env().EXCHANGE_SYNC_MONTHLY_BUDGET
+	callsPerSync := providers.callsPerSync(syncRequests())
+	switch mode := cfg.Env().EXCHANGE_SYNC_MODE; mode {
+	case scheduler.ModeFixed:
+		return scheduler.NewFixedScheduler(usage, providers.names, budget, callsPerSync, cfg.Env().EXCHANGE_SYNC_SLEEP), nil
+	case scheduler.ModeBudget:
+		if budget <= 0 {
+			return nil, fmt.Errorf("sync mode %s requires a positive EXCHANGE_SYNC_MONTHLY_BUDGET", mode)
+		}
+
+		return scheduler.NewBudgetScheduler(usage, providers.names, budget, callsPerSync, cfg.Env().EXCHANGE_SYNC_MIN_INTERVAL), nil
+	default:
+		return nil, fmt.Errorf("unknown sync mode %q, expected %s or %s", mode, scheduler.ModeFixed, scheduler.ModeBudget)
+	}
+}
+
+// runSync syncs every target of each source currency in one batch. It is
+// refused up front when every provider has used up its monthly quota.
+func runSync(ctx context.Context, useCase entity.SyncExchangeRatesUseCase, limiters exchangerate.RateLimiters) {
+	if err := limiters.CheckBudget(ctx); err != nil {
+		log.Error().Err(err).Msg("sync refused")
+		return
+	}
+
+	for _, request := range syncRequests() {
+		_, err := useCase.Execute(ctx, request)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to sync exchange rates from %s", request.SourceCurrency)
+		}
+	}
+}
+
+// syncRequests batches the configured targets of each source currency.
+func syncRequests() []entity.SyncExchangeRatesRequest {
 	currenciesFrom := cfg.Env().CurrenciesFrom()
 	currenciesTo := cfg.Env().CurrenciesTo()
+	requests := make([]entity.SyncExchangeRatesRequest, 0, len(currenciesFrom))
 	for _, from := range currenciesFrom {
+		targets := make([]string, 0, len(currenciesTo))
 		for _, to := range currenciesTo {
 			if from == to {
 				continue
 			}
 
-			_, err := useCase.Execute(ctx, entity.SyncExchangeRateRequest{
-				SourceCurrency: from,
-				TargetCurrency: to,
-			})
-			if err != nil {
-				log.Error().Err(err).Msg("failed to sync exchange rate")
-			}
+			targets = append(targets, to)
 		}
+
+		if len(targets) == 0 {
+			continue
+		}
+
+		requests = append(requests, entity.SyncExchangeRatesRequest{
+			SourceCurrency:   from,
+			TargetCurrencies: targets,
+		})
 	}
+
+	return requests
 }
 
 func init() {
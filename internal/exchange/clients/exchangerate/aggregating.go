@@ -0,0 +1,223 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"sort"
+	"sync"
+	"time"
+)
+
+// aggregationPrecision matches the scale rates are stored with.
+const aggregationPrecision = 15
+
+// ErrNotEnoughQuotes is returned when too few providers agree on a rate.
+var ErrNotEnoughQuotes = errors.New("not enough exchange rate quotes")
+
+type quote struct {
+	provider string
+	rate     decimal.Decimal
+}
+
+// aggregatingClient asks every provider at once and answers with the median of
+// their quotes. Quotes deviating from the median by more than maxDeviation, a
+// fraction of it, are rejected as outliers.
+type aggregatingClient struct {
+	providers    []Provider
+	timeout      time.Duration
+	maxDeviation decimal.Decimal
+	minQuotes    int
+}
+
+func (a *aggregatingClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	results := a.collect(ctx, func(ctx context.Context, client Client) (map[string]*GetExchangeRateResponse, error) {
+		response, err := client.GetExchangeRate(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]*GetExchangeRateResponse{request.To: response}, nil
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return a.aggregate(request, results)
+}
+
+// GetExchangeRates asks every provider for all targets at once and aggregates
+// each target on its own.
+func (a *aggregatingClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return a.getRates(ctx, base, targets, latestRates)
+}
+
+func (a *aggregatingClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return a.getRates(ctx, base, targets, historicalRates(date))
+}
+
+func (a *aggregatingClient) getRates(ctx context.Context, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	results := a.collect(ctx, func(ctx context.Context, client Client) (map[string]*GetExchangeRateResponse, error) {
+		return fetch(ctx, client, base, targets)
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		response, err := a.aggregate(GetExchangeRateRequest{From: base, To: target}, results)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		rates[target] = response
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+// providerResult is what a provider answered to a call.
+type providerResult struct {
+	provider Provider
+	rates    map[string]*GetExchangeRateResponse
+	err      error
+}
+
+// collect calls every provider in parallel with fetch.
+func (a *aggregatingClient) collect(ctx context.Context, fetch func(ctx context.Context, client Client) (map[string]*GetExchangeRateResponse, error)) []providerResult {
+	results := make([]providerResult, len(a.providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range a.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			callCtx := ctx
+			if a.timeout > 0 {
+				var cancel context.CancelFunc
+				callCtx, cancel = context.WithTimeout(ctx, a.timeout)
+				defer cancel()
+			}
+
+			rates, err := fetch(callCtx, provider.Client)
+			results[i] = providerResult{provider: provider, rates: rates, err: err}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// aggregate takes the median of the providers' quotes for request.
+func (a *aggregatingClient) aggregate(request GetExchangeRateRequest, results []providerResult) (*GetExchangeRateResponse, error) {
+	quotes, errs := quotesFor(request, results)
+	if len(quotes) < a.minQuotes {
+		return nil, fmt.Errorf("%w for %s-%s: got %d, need %d: %w", ErrNotEnoughQuotes, request.From, request.To, len(quotes), a.minQuotes, errors.Join(errs...))
+	}
+
+	accepted := a.rejectOutliers(request, quotes)
+	if len(accepted) < a.minQuotes {
+		return nil, fmt.Errorf("%w for %s-%s: %d agree within %s, need %d", ErrNotEnoughQuotes, request.From, request.To, len(accepted), a.maxDeviation, a.minQuotes)
+	}
+
+	rate := median(accepted)
+	providers := make([]string, 0, len(accepted))
+	for _, q := range accepted {
+		providers = append(providers, q.provider)
+	}
+
+	// Quotes are sorted by rate, so the extremes bound the spread.
+	spread := accepted[len(accepted)-1].rate.Sub(accepted[0].rate).DivRound(rate, aggregationPrecision)
+	return &GetExchangeRateResponse{
+		Rate:      rate,
+		Providers: providers,
+		Spread:    decimal.NewNullDecimal(spread),
+	}, nil
+}
+
+// quotesFor returns the usable quotes for request sorted by rate, along with
+// why the other providers have none.
+func quotesFor(request GetExchangeRateRequest, results []providerResult) ([]quote, []error) {
+	quotes := make([]quote, 0, len(results))
+	var errs []error
+	for _, result := range results {
+		var err error
+		response, ok := result.rates[request.To]
+		switch {
+		case !ok && result.err != nil:
+			err = result.err
+		case !ok:
+			err = fmt.Errorf("%w: %s", ErrUnknownCurrency, request.To)
+		case !response.Rate.IsPositive():
+			err = fmt.Errorf("non-positive rate %s", response.Rate)
+		}
+
+		if err != nil {
+			log.Warn().Err(err).Msgf("exchange rate provider %s failed for %s-%s", result.provider.Name, request.From, request.To)
+			errs = append(errs, fmt.Errorf("%s: %w", result.provider.Name, err))
+			continue
+		}
+
+		quotes = append(quotes, quote{provider: result.provider.Name, rate: response.Rate})
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].rate.LessThan(quotes[j].rate)
+	})
+
+	return quotes, errs
+}
+
+// rejectOutliers keeps the quotes within maxDeviation of their median. A
+// non-positive maxDeviation keeps every quote.
+func (a *aggregatingClient) rejectOutliers(request GetExchangeRateRequest, quotes []quote) []quote {
+	if !a.maxDeviation.IsPositive() || len(quotes) == 0 {
+		return quotes
+	}
+
+	m := median(quotes)
+	accepted := make([]quote, 0, len(quotes))
+	for _, q := range quotes {
+		deviation := q.rate.Sub(m).Abs().Div(m)
+		if deviation.GreaterThan(a.maxDeviation) {
+			log.Warn().Msgf("rejected %s-%s quote %s from %s, %s away from the median %s", request.From, request.To, q.rate, q.provider, deviation.StringFixed(4), m)
+			continue
+		}
+
+		accepted = append(accepted, q)
+	}
+
+	return accepted
+}
+
+// median expects quotes sorted by rate.
+func median(quotes []quote) decimal.Decimal {
+	middle := len(quotes) / 2
+	if len(quotes)%2 == 1 {
+		return quotes[middle].rate
+	}
+
+	return quotes[middle-1].rate.Add(quotes[middle].rate).DivRound(decimal.NewFromInt(2), aggregationPrecision)
+}
+
+// NewAggregatingClient returns a Client answering with the median quote of all
+// providers. maxDeviation is the fraction of the median a quote may be off by,
+// and at least minQuotes quotes must remain for a rate to be returned.
+func NewAggregatingClient(providers []Provider, timeout time.Duration, maxDeviation decimal.Decimal, minQuotes int) Client {
+	if minQuotes < 1 {
+		minQuotes = 1
+	}
+
+	return &aggregatingClient{
+		providers:    providers,
+		timeout:      timeout,
+		maxDeviation: maxDeviation,
+		minQuotes:    minQuotes,
+	}
+}
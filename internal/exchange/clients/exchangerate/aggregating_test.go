@@ -0,0 +1,212 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func aggregatingProviders(ctrl *gomock.Controller, names ...string) ([]exchangerate.Provider, map[string]*mocks.MockClient) {
+	providers := make([]exchangerate.Provider, 0, len(names))
+	clients := map[string]*mocks.MockClient{}
+	for _, name := range names {
+		client := mocks.NewMockClient(ctrl)
+		clients[name] = client
+		providers = append(providers, exchangerate.Provider{Name: name, Client: client})
+	}
+
+	return providers, clients
+}
+
+func TestAggregatingClient_ReturnsMedian(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b", "c")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.02"), 1)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.30"), nil)
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.20"), nil)
+	clients["c"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.Equal(t, []string{"b", "c", "a"}, response.Providers)
+	require.True(t, response.Spread.Valid)
+	assert.Equal(t, "0.019047619047619", response.Spread.Decimal.String()) // (5.30 - 5.20) / 5.25
+}
+
+func TestAggregatingClient_AveragesEvenQuotes(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.Zero, 2)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.20"), nil)
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.30"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.Equal(t, []string{"a", "b"}, response.Providers)
+}
+
+func TestAggregatingClient_RejectsOutliers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b", "c")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.02"), 1)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("6.00"), nil)
+	clients["c"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.24"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.245", response.Rate.String())
+	assert.Equal(t, []string{"c", "a"}, response.Providers)
+}
+
+func TestAggregatingClient_IgnoresFailedProviders(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b", "c")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.02"), 1)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("timeout"))
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("0"), nil)
+	clients["c"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.Equal(t, []string{"c"}, response.Providers)
+	assert.True(t, response.Spread.Decimal.IsZero())
+}
+
+func TestAggregatingClient_NotEnoughQuotes(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b", "c")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.02"), 2)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("quota exceeded"))
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("bad gateway"))
+	clients["c"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, exchangerate.ErrNotEnoughQuotes)
+	assert.ErrorContains(t, err, "a: quota exceeded")
+	assert.ErrorContains(t, err, "b: bad gateway")
+}
+
+func TestAggregatingClient_NotEnoughQuotesAfterRejectingOutliers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.01"), 2)
+
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.00"), nil)
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("6.00"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, exchangerate.ErrNotEnoughQuotes)
+}
+
+func TestAggregatingClient_QueriesProvidersInParallel(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.Zero, 1)
+
+	// Each provider waits for the other to be called, so a sequential client would time out.
+	started := make(chan struct{}, 2)
+	slow := func(ctx context.Context, request exchangerate.GetExchangeRateRequest) (*exchangerate.GetExchangeRateResponse, error) {
+		started <- struct{}{}
+		for len(started) < 2 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+		}
+
+		return rateResponse("5.25"), nil
+	}
+	clients["a"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).DoAndReturn(slow)
+	clients["b"].EXPECT().GetExchangeRate(gomock.Any(), usdBRL).DoAndReturn(slow)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, response.Providers)
+}
+
+func TestAggregatingClient_GetExchangeRates_AggregatesEachTarget(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providers, clients := aggregatingProviders(ctrl, "a", "b")
+	client := exchangerate.NewAggregatingClient(providers, time.Second, decimal.RequireFromString("0.02"), 2)
+	targets := []string{"BRL", "EUR"}
+
+	clients["a"].EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", targets).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.20"), "EUR": rateResponse("0.92")}, nil)
+	clients["b"].EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", targets).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.30")}, exchangerate.ErrUnknownCurrency)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", targets)
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrNotEnoughQuotes) // Only a quoted EUR
+	require.Len(t, rates, 1)
+	assert.Equal(t, "5.25", rates["BRL"].Rate.String())
+	assert.Equal(t, []string{"a", "b"}, rates["BRL"].Providers)
+}
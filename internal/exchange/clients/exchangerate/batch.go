@@ -0,0 +1,68 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// batcher is implemented by clients getting every target of a base in one call.
+type batcher interface {
+	batches() bool
+}
+
+// Batches reports whether client gets every target of a base in a single call
+// rather than one call per target.
+func Batches(client Client) bool {
+	b, ok := client.(batcher)
+	return ok && b.batches()
+}
+
+// fetchRates gets the rates from base to each target from client, the way
+// GetExchangeRates or GetHistoricalRates do, so decorators handle both alike.
+type fetchRates func(ctx context.Context, client Client, base string, targets []string) (map[string]*GetExchangeRateResponse, error)
+
+func latestRates(ctx context.Context, client Client, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return client.GetExchangeRates(ctx, base, targets)
+}
+
+func historicalRates(date time.Time) fetchRates {
+	return func(ctx context.Context, client Client, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+		return client.GetHistoricalRates(ctx, base, targets, date)
+	}
+}
+
+// getEachExchangeRate gets rates one target at a time with get, for providers that can't batch.
+func getEachExchangeRate(ctx context.Context, base string, targets []string, get func(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error)) (map[string]*GetExchangeRateResponse, error) {
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		response, err := get(ctx, GetExchangeRateRequest{From: base, To: target})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s-%s: %w", base, target, err))
+			continue
+		}
+
+		rates[target] = response
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+// missingTargets returns the targets without a rate, in order.
+func missingTargets(targets []string, rates map[string]*GetExchangeRateResponse) []string {
+	missing := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if _, ok := rates[target]; !ok {
+			missing = append(missing, target)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,202 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the provider while its breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// BreakerStatus is a snapshot of a provider's circuit breaker.
+type BreakerStatus struct {
+	Provider            string       `json:"provider" example:"freecurrencyapi"`
+	State               BreakerState `json:"state" enum:"closed,open,half-open"`
+	Since               time.Time    `json:"since" description:"When the breaker entered its state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+}
+
+// circuitBreakerClient stops calling a provider after failureThreshold
+// consecutive failures. Once openTimeout has passed it lets a single trial call
+// through at a time, and closes again after halfOpenSuccesses of them succeed.
+type circuitBreakerClient struct {
+	provider          Provider
+	failureThreshold  int
+	openTimeout       time.Duration
+	halfOpenSuccesses int
+
+	mu        sync.Mutex
+	state     BreakerState
+	since     time.Time
+	failures  int
+	successes int
+	trial     bool
+}
+
+func (b *circuitBreakerClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+
+	response, err := b.provider.Client.GetExchangeRate(ctx, request)
+	b.release(ctx, err)
+	return response, err
+}
+
+// GetExchangeRates counts as a failure only when no rate came back.
+func (b *circuitBreakerClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return b.getRates(ctx, base, targets, latestRates)
+}
+
+func (b *circuitBreakerClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return b.getRates(ctx, base, targets, historicalRates(date))
+}
+
+func (b *circuitBreakerClient) getRates(ctx context.Context, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+
+	rates, err := fetch(ctx, b.provider.Client, base, targets)
+	if len(rates) > 0 {
+		b.release(ctx, nil)
+	} else {
+		b.release(ctx, err)
+	}
+
+	return rates, err
+}
+
+func (b *circuitBreakerClient) BreakerStatus() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(time.Now())
+	return BreakerStatus{
+		Provider:            b.provider.Name,
+		State:               b.state,
+		Since:               b.since,
+		ConsecutiveFailures: b.failures,
+	}
+}
+
+// acquire lets a call through, unless the breaker is open or a half-open trial is already running.
+func (b *circuitBreakerClient) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(time.Now())
+	switch b.state {
+	case BreakerOpen:
+		return fmt.Errorf("%w for %s until %s", ErrCircuitOpen, b.provider.Name, b.since.Add(b.openTimeout).Format(time.RFC3339))
+	case BreakerHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%w for %s while a trial call runs", ErrCircuitOpen, b.provider.Name)
+		}
+
+		b.trial = true
+	}
+
+	return nil
+}
+
+func (b *circuitBreakerClient) release(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasTrial := b.trial
+	b.trial = false
+
+	// Neither the caller giving up, an unknown currency or date, a missing feature nor our own quota says anything about the provider's health.
+	if err != nil && (ctx.Err() != nil || errors.Is(err, ErrUnknownCurrency) || errors.Is(err, ErrNoRatesForDate) || errors.Is(err, ErrHistoricalUnsupported) || errors.Is(err, ErrQuotaExhausted)) {
+		return
+	}
+
+	now := time.Now()
+	if err != nil {
+		b.failures++
+		if wasTrial || b.failures >= b.failureThreshold {
+			b.transition(BreakerOpen, now)
+		}
+
+		return
+	}
+
+	b.failures = 0
+	if wasTrial {
+		b.successes++
+		if b.successes >= b.halfOpenSuccesses {
+			b.transition(BreakerClosed, now)
+		}
+	}
+}
+
+// refresh moves an open breaker to half-open once openTimeout has passed.
+func (b *circuitBreakerClient) refresh(now time.Time) {
+	if b.state == BreakerOpen && !now.Before(b.since.Add(b.openTimeout)) {
+		b.transition(BreakerHalfOpen, now)
+	}
+}
+
+func (b *circuitBreakerClient) transition(state BreakerState, now time.Time) {
+	if state == b.state {
+		b.since = now
+		return
+	}
+
+	event := log.Info()
+	if state == BreakerOpen {
+		event = log.Warn()
+	}
+
+	event.Msgf("circuit breaker for exchange rate provider %s changed from %s to %s", b.provider.Name, b.state, state)
+	b.state = state
+	b.since = now
+	b.successes = 0
+}
+
+// NewCircuitBreakerClient wraps provider in a circuit breaker. It opens after
+// failureThreshold consecutive failures, stays open for openTimeout and closes
+// after halfOpenSuccesses successful trial calls.
+func NewCircuitBreakerClient(provider Provider, failureThreshold int, openTimeout time.Duration, halfOpenSuccesses int) BreakerClient {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	if halfOpenSuccesses < 1 {
+		halfOpenSuccesses = 1
+	}
+
+	return &circuitBreakerClient{
+		provider:          provider,
+		failureThreshold:  failureThreshold,
+		openTimeout:       openTimeout,
+		halfOpenSuccesses: halfOpenSuccesses,
+		state:             BreakerClosed,
+		since:             time.Now(),
+	}
+}
+
+// Breakers reports the state of several circuit breakers, in order.
+type Breakers []BreakerClient
+
+func (b Breakers) ProviderStatuses() []BreakerStatus {
+	statuses := make([]BreakerStatus, 0, len(b))
+	for _, breaker := range b {
+		statuses = append(statuses, breaker.BreakerStatus())
+	}
+
+	return statuses
+}
@@ -0,0 +1,194 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerClient_OpensAfterConsecutiveFailures(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 2, time.Minute, 1)
+
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, errors.New("bad gateway")).
+		Times(2)
+
+	// Act
+	_, firstErr := breaker.GetExchangeRate(context.Background(), usdBRL)
+	_, secondErr := breaker.GetExchangeRate(context.Background(), usdBRL)
+	_, shortCircuitedErr := breaker.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.EqualError(t, firstErr, "bad gateway")
+	assert.EqualError(t, secondErr, "bad gateway")
+	assert.ErrorIs(t, shortCircuitedErr, exchangerate.ErrCircuitOpen)
+
+	status := breaker.BreakerStatus()
+	assert.Equal(t, "primary", status.Provider)
+	assert.Equal(t, exchangerate.BreakerOpen, status.State)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+}
+
+func TestCircuitBreakerClient_SuccessResetsFailures(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 2, time.Minute, 1)
+
+	gomock.InOrder(
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("bad gateway")),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("bad gateway")),
+	)
+
+	// Act
+	for range 3 {
+		_, _ = breaker.GetExchangeRate(context.Background(), usdBRL)
+	}
+
+	// Assert
+	status := breaker.BreakerStatus()
+	assert.Equal(t, exchangerate.BreakerClosed, status.State)
+	assert.Equal(t, 1, status.ConsecutiveFailures)
+}
+
+func TestCircuitBreakerClient_IgnoresUnknownCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 1, time.Minute, 1)
+
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, exchangerate.ErrUnknownCurrency).
+		Times(2)
+
+	// Act
+	_, _ = breaker.GetExchangeRate(context.Background(), usdBRL)
+	_, err := breaker.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.Equal(t, exchangerate.BreakerClosed, breaker.BreakerStatus().State)
+}
+
+func TestCircuitBreakerClient_CountsMalformedResponsesButNotMissingHistory(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 1, time.Minute, 1)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	inner.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(nil, exchangerate.ErrHistoricalUnsupported)
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, exchangerate.ErrMalformedResponse)
+
+	// Act
+	_, unsupportedErr := breaker.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, date)
+	stateAfterUnsupported := breaker.BreakerStatus().State
+	_, malformedErr := breaker.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.ErrorIs(t, unsupportedErr, exchangerate.ErrHistoricalUnsupported)
+	assert.Equal(t, exchangerate.BreakerClosed, stateAfterUnsupported)
+	assert.ErrorIs(t, malformedErr, exchangerate.ErrMalformedResponse)
+	assert.Equal(t, exchangerate.BreakerOpen, breaker.BreakerStatus().State)
+}
+
+func TestCircuitBreakerClient_HalfOpenTrialCloses(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 1, 20*time.Millisecond, 1)
+
+	gomock.InOrder(
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("bad gateway")),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil),
+	)
+
+	_, _ = breaker.GetExchangeRate(context.Background(), usdBRL)
+	require.Equal(t, exchangerate.BreakerOpen, breaker.BreakerStatus().State)
+
+	// Act
+	time.Sleep(30 * time.Millisecond)
+	halfOpen := breaker.BreakerStatus().State
+	response, err := breaker.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Equal(t, exchangerate.BreakerHalfOpen, halfOpen)
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.Equal(t, exchangerate.BreakerClosed, breaker.BreakerStatus().State)
+}
+
+func TestCircuitBreakerClient_HalfOpenTrialFailureReopens(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	breaker := exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: inner}, 3, 20*time.Millisecond, 1)
+
+	// Three failures open the breaker; a single failed trial is enough to reopen it.
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, errors.New("bad gateway")).
+		Times(4)
+
+	for range 3 {
+		_, _ = breaker.GetExchangeRate(context.Background(), usdBRL)
+	}
+
+	// Act
+	time.Sleep(30 * time.Millisecond)
+	_, trialErr := breaker.GetExchangeRate(context.Background(), usdBRL)
+	_, err := breaker.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.EqualError(t, trialErr, "bad gateway")
+	assert.ErrorIs(t, err, exchangerate.ErrCircuitOpen)
+	assert.Equal(t, exchangerate.BreakerOpen, breaker.BreakerStatus().State)
+}
+
+func TestBreakers_ProviderStatuses(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	breakers := exchangerate.Breakers{
+		exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "primary", Client: mocks.NewMockClient(ctrl)}, 1, time.Minute, 1),
+		exchangerate.NewCircuitBreakerClient(exchangerate.Provider{Name: "secondary", Client: mocks.NewMockClient(ctrl)}, 1, time.Minute, 1),
+	}
+
+	// Act
+	statuses := breakers.ProviderStatuses()
+
+	// Assert
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "primary", statuses[0].Provider)
+	assert.Equal(t, "secondary", statuses[1].Provider)
+	assert.Equal(t, exchangerate.BreakerClosed, statuses[1].State)
+}
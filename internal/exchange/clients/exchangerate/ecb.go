@@ -0,0 +1,153 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"time"
+)
+
+// ecbBase is the currency every ECB reference rate is quoted against.
+const ecbBase = "EUR"
+
+// ecbEnvelope is the gesmes:Envelope of the ECB reference rate feeds, holding
+// one Cube per day, newest first, each with a Cube per currency.
+type ecbEnvelope struct {
+	Days []ecbDay `xml:"Cube>Cube"`
+}
+
+type ecbDay struct {
+	Time  string    `xml:"time,attr"`
+	Rates []ecbRate `xml:"Cube"`
+}
+
+type ecbRate struct {
+	Currency string          `xml:"currency,attr"`
+	Rate     decimal.Decimal `xml:"rate,attr"`
+}
+
+// ecbClient reads the European Central Bank's daily and 90-day reference rate
+// feeds. The ECB only publishes EUR-based rates, so any other pair is crossed
+// through EUR.
+type ecbClient struct {
+	http          *http.Client
+	dailyURL      string
+	historicalURL string
+}
+
+func (e ecbClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	rates, err := e.GetExchangeRates(ctx, request.From, []string{request.To})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates[request.To], nil
+}
+
+// GetExchangeRates reads every target from a single daily feed.
+func (e ecbClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	envelope, err := e.fetch(ctx, e.dailyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(envelope.Days) == 0 {
+		return nil, fmt.Errorf("%w: ecb daily feed has no reference rates", ErrMalformedResponse)
+	}
+
+	return envelope.Days[0].crossRates(base, targets)
+}
+
+// GetHistoricalRates reads the rates of date from the 90-day feed. The ECB
+// doesn't publish on weekends and TARGET holidays, so those days take the last
+// rates published before them.
+func (e ecbClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	envelope, err := e.fetch(ctx, e.historicalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	day := date.UTC().Format(time.DateOnly)
+	for _, published := range envelope.Days {
+		// Days are newest first and their dates compare as text.
+		if published.Time <= day {
+			return published.crossRates(base, targets)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: ecb has no reference rates on or before %s", ErrNoRatesForDate, day)
+}
+
+func (e ecbClient) fetch(ctx context.Context, url string) (*ecbEnvelope, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResponse, err := e.http.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, newStatusError(httpResponse)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(httpResponse.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode ecb feed: %w", ErrMalformedResponse, err)
+	}
+
+	return &envelope, nil
+}
+
+// crossRates divides the EUR rate of each target by the one of base.
+func (d ecbDay) crossRates(base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	euroRates := map[string]decimal.Decimal{ecbBase: decimal.NewFromInt(1)}
+	for _, rate := range d.Rates {
+		euroRates[rate.Currency] = rate.Rate
+	}
+
+	baseRate, ok := euroRates[base]
+	if !ok || !baseRate.IsPositive() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, base)
+	}
+
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		targetRate, ok := euroRates[target]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s-%s: %w", base, target, ErrUnknownCurrency))
+			continue
+		}
+
+		rate := targetRate
+		if base != ecbBase {
+			rate = targetRate.DivRound(baseRate, aggregationPrecision)
+		}
+
+		rates[target] = &GetExchangeRateResponse{Rate: rate}
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+func (e ecbClient) batches() bool {
+	return true
+}
+
+// NewECBClient returns a Client reading the ECB daily feed at dailyURL and the
+// 90-day feed at historicalURL.
+func NewECBClient(http *http.Client, dailyURL, historicalURL string) Client {
+	return &ecbClient{
+		http:          http,
+		dailyURL:      dailyURL,
+		historicalURL: historicalURL,
+	}
+}
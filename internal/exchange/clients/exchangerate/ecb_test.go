@@ -0,0 +1,153 @@
+package exchangerate_test
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newECBClient serves the recorded feeds in testdata.
+func newECBClient(t *testing.T) exchangerate.Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eurofxref-daily.xml", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/ecb-daily.xml")
+	})
+	mux.HandleFunc("/eurofxref-hist-90d.xml", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/ecb-hist-90d.xml")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return exchangerate.NewECBClient(server.Client(), server.URL+"/eurofxref-daily.xml", server.URL+"/eurofxref-hist-90d.xml")
+}
+
+func TestECBClient_GetExchangeRates_EuroBase(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "EUR", []string{"USD", "BRL"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, exchangerate.Batches(client))
+	assert.Equal(t, "1.089", rates["USD"].Rate.String())
+	assert.Equal(t, "5.4378", rates["BRL"].Rate.String())
+}
+
+func TestECBClient_GetExchangeRates_CrossesThroughEuro(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR", "XYZ"})
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, "USD-XYZ")
+	require.Len(t, rates, 2)
+	assert.Equal(t, "4.993388429752066", rates["BRL"].Rate.String()) // 5.4378 / 1.0890
+	assert.Equal(t, "0.918273645546373", rates["EUR"].Rate.String()) // 1 / 1.0890
+}
+
+func TestECBClient_GetExchangeRate_UnknownBase(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), exchangerate.GetExchangeRateRequest{From: "XYZ", To: "BRL"})
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+}
+
+func TestECBClient_GetHistoricalRates(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "4.973546910755149", rates["BRL"].Rate.String()) // 5.4336 / 1.0925
+}
+
+func TestECBClient_GetHistoricalRates_WeekendTakesLastPublished(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "EUR", []string{"USD"}, time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "1.089", rates["USD"].Rate.String()) // Friday's rate
+}
+
+func TestECBClient_GetHistoricalRates_BeforeFeed(t *testing.T) {
+	// Arrange
+	client := newECBClient(t)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "EUR", []string{"USD"}, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Nil(t, rates)
+	assert.ErrorIs(t, err, exchangerate.ErrNoRatesForDate)
+}
+
+func TestECBClient_GetExchangeRates_StatusError(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := exchangerate.NewECBClient(server.Client(), server.URL, server.URL)
+
+	// Act
+	_, err := client.GetExchangeRates(context.Background(), "EUR", []string{"USD"})
+
+	// Assert
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.True(t, exchangerate.IsRetryable(err))
+}
+
+func TestECBClient_GetExchangeRates_MalformedFeed(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{"not xml", "<html>maintenance"},
+		{"no reference rates", `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube></Cube></gesmes:Envelope>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := exchangerate.NewECBClient(server.Client(), server.URL, server.URL)
+
+			// Act
+			rates, err := client.GetExchangeRates(context.Background(), "EUR", []string{"USD"})
+
+			// Assert
+			assert.Nil(t, rates)
+			assert.ErrorIs(t, err, exchangerate.ErrMalformedResponse)
+		})
+	}
+}
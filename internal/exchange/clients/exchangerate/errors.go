@@ -0,0 +1,118 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownCurrency is returned when a provider has no rate for a currency.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// ErrNoRatesForDate is returned when a provider has no historical rates for a date.
+var ErrNoRatesForDate = errors.New("no exchange rates for date")
+
+// ErrHistoricalUnsupported is returned by providers that can't get historical rates at all.
+var ErrHistoricalUnsupported = errors.New("historical exchange rates not supported")
+
+// ErrMalformedResponse is returned when a provider answers with a body that
+// doesn't have the expected shape, which says the provider, or our mapping of
+// it, is broken.
+var ErrMalformedResponse = errors.New("malformed provider response")
+
+// StatusError is an unsuccessful HTTP status returned by a provider.
+type StatusError struct {
+	StatusCode int
+
+	// RetryAfter is how long the provider asked us to wait, when it said so.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// IsRetryable reports whether a failed call may succeed when made again:
+// network errors, timeouts, 5xx and 429 are retryable, while other 4xx, unknown
+// currencies and anything unrecognised are not. The failures of a batch, joined
+// with errors.Join, are retryable when any of them is.
+func IsRetryable(err error) bool {
+	for wrapped := err; wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		if joined, ok := wrapped.(interface{ Unwrap() []error }); ok {
+			return slices.ContainsFunc(joined.Unwrap(), IsRetryable)
+		}
+	}
+
+	if errors.Is(err, ErrUnknownCurrency) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func newStatusError(response *http.Response) *StatusError {
+	return &StatusError{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+
+	return 0
+}
+
+// statusTransport turns unsuccessful responses into a StatusError, for HTTP
+// based SDKs that don't tell callers which status they got.
+type statusTransport struct {
+	base http.RoundTripper
+}
+
+func (s statusTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := s.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < http.StatusBadRequest {
+		return response, nil
+	}
+
+	statusErr := newStatusError(response)
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	return nil, statusErr
+}
+
+// NewStatusTransport wraps base so that 4xx and 5xx responses fail with a StatusError.
+func NewStatusTransport(base http.RoundTripper) http.RoundTripper {
+	return statusTransport{base: base}
+}
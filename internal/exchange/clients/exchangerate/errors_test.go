@@ -0,0 +1,86 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"too many requests", &exchangerate.StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", &exchangerate.StatusError{StatusCode: http.StatusInternalServerError}, true},
+		{"gateway timeout", fmt.Errorf("latest: %w", &exchangerate.StatusError{StatusCode: http.StatusGatewayTimeout}), true},
+		{"network error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"unauthorized", &exchangerate.StatusError{StatusCode: http.StatusUnauthorized}, false},
+		{"not found", &exchangerate.StatusError{StatusCode: http.StatusNotFound}, false},
+		{"unknown currency", fmt.Errorf("%w: XYZ", exchangerate.ErrUnknownCurrency), false},
+		{"canceled", context.Canceled, false},
+		{"batch with a retryable failure", errors.Join(fmt.Errorf("USD-XYZ: %w", exchangerate.ErrUnknownCurrency), &exchangerate.StatusError{StatusCode: http.StatusBadGateway}), true},
+		{"batch of permanent failures", fmt.Errorf("latest: %w", errors.Join(exchangerate.ErrUnknownCurrency, &exchangerate.StatusError{StatusCode: http.StatusNotFound})), false},
+		{"unrecognised", errors.New("boom"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			retryable := exchangerate.IsRetryable(tc.err)
+
+			// Assert
+			assert.Equal(t, tc.expected, retryable)
+		})
+	}
+}
+
+func TestStatusTransport_ReportsStatusAndRetryAfter(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: exchangerate.NewStatusTransport(http.DefaultTransport)}
+
+	// Act
+	response, err := client.Get(server.URL)
+
+	// Assert
+	assert.Nil(t, response)
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, 7*time.Second, statusErr.RetryAfter)
+	assert.True(t, exchangerate.IsRetryable(err))
+}
+
+func TestStatusTransport_PassesSuccessfulResponses(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: exchangerate.NewStatusTransport(http.DefaultTransport)}
+
+	// Act
+	response, err := client.Get(server.URL)
+
+	// Assert
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
@@ -0,0 +1,174 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"sync"
+	"time"
+)
+
+// ErrNoProviderAvailable is returned when every provider failed to answer.
+var ErrNoProviderAvailable = errors.New("no exchange rate provider available")
+
+// Provider is a Client with a name, so composite clients can tell them apart.
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+// failoverClient asks its providers in order until one answers. A provider that
+// fails is skipped for a cool-down period, unless every provider is cooling down.
+type failoverClient struct {
+	providers []Provider
+	timeout   time.Duration
+	cooldown  time.Duration
+
+	mu             sync.Mutex
+	unhealthyUntil map[string]time.Time
+}
+
+func (f *failoverClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	var errs []error
+	for _, provider := range f.candidates() {
+		response, err := f.getExchangeRate(ctx, provider, request)
+		if err == nil {
+			f.markHealthy(provider)
+			return response, nil
+		}
+
+		// The caller gave up, which says nothing about the provider's health.
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		log.Warn().Err(err).Msgf("exchange rate provider %s failed for %s-%s, cooling down for %s", provider.Name, request.From, request.To, f.cooldown)
+		f.markUnhealthy(provider)
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrNoProviderAvailable, errors.Join(errs...))
+}
+
+func (f *failoverClient) getExchangeRate(ctx context.Context, provider Provider, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	return provider.Client.GetExchangeRate(ctx, request)
+}
+
+// GetExchangeRates asks each provider for the targets the previous ones couldn't
+// get. Only a provider returning no rate at all is considered unhealthy.
+func (f *failoverClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return f.getRates(ctx, base, targets, latestRates)
+}
+
+// GetHistoricalRates fails over like GetExchangeRates.
+func (f *failoverClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return f.getRates(ctx, base, targets, historicalRates(date))
+}
+
+func (f *failoverClient) getRates(ctx context.Context, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	missing := targets
+	var errs []error
+	for _, provider := range f.candidates() {
+		got, err := f.fetch(ctx, provider, base, missing, fetch)
+		if errors.Is(err, ErrHistoricalUnsupported) {
+			// Not a failure, so the provider is neither cooled down nor reported.
+			continue
+		}
+
+		for target, response := range got {
+			rates[target] = response
+		}
+
+		missing = missingTargets(missing, rates)
+		if len(got) > 0 || err == nil {
+			f.markHealthy(provider)
+		}
+
+		if err == nil || len(missing) == 0 {
+			return rates, nil
+		}
+
+		if ctx.Err() != nil {
+			return rates, ctx.Err()
+		}
+
+		if len(got) == 0 {
+			log.Warn().Err(err).Msgf("exchange rate provider %s failed for %s, cooling down for %s", provider.Name, base, f.cooldown)
+			f.markUnhealthy(provider)
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+	}
+
+	if len(errs) == 0 {
+		return rates, fmt.Errorf("%w by any provider", ErrHistoricalUnsupported)
+	}
+
+	return rates, fmt.Errorf("%w: %w", ErrNoProviderAvailable, errors.Join(errs...))
+}
+
+func (f *failoverClient) fetch(ctx context.Context, provider Provider, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	return fetch(ctx, provider.Client, base, targets)
+}
+
+// candidates returns the healthy providers in order or, when none is healthy,
+// all of them, so a global outage doesn't outlive the cool-down needlessly.
+func (f *failoverClient) candidates() []Provider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	healthy := make([]Provider, 0, len(f.providers))
+	for _, provider := range f.providers {
+		if now.Before(f.unhealthyUntil[provider.Name]) {
+			continue
+		}
+
+		healthy = append(healthy, provider)
+	}
+
+	if len(healthy) == 0 {
+		return f.providers
+	}
+
+	return healthy
+}
+
+func (f *failoverClient) markHealthy(provider Provider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.unhealthyUntil, provider.Name)
+}
+
+func (f *failoverClient) markUnhealthy(provider Provider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.unhealthyUntil[provider.Name] = time.Now().Add(f.cooldown)
+}
+
+// NewFailoverClient returns a Client trying providers in the given order. Each
+// call to a provider is bounded by timeout, when positive.
+func NewFailoverClient(providers []Provider, timeout, cooldown time.Duration) Client {
+	return &failoverClient{
+		providers:      providers,
+		timeout:        timeout,
+		cooldown:       cooldown,
+		unhealthyUntil: map[string]time.Time{},
+	}
+}
@@ -0,0 +1,344 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var usdBRL = exchangerate.GetExchangeRateRequest{From: "USD", To: "BRL"}
+
+func rateResponse(rate string) *exchangerate.GetExchangeRateResponse {
+	return &exchangerate.GetExchangeRateResponse{Rate: decimal.RequireFromString(rate)}
+}
+
+func TestFailoverClient_UsesFirstHealthyProvider(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(rateResponse("5.25"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestFailoverClient_FailsOverAndCoolsDown(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, errors.New("quota exceeded")).
+		Times(1) // Skipped while cooling down
+	secondary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(rateResponse("5.30"), nil).
+		Times(2)
+
+	// Act
+	first, firstErr := client.GetExchangeRate(context.Background(), usdBRL)
+	second, secondErr := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "5.3", first.Rate.String())
+	assert.Equal(t, "5.3", second.Rate.String())
+}
+
+func TestFailoverClient_RetriesProviderAfterCooldown(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	cooldown := 20 * time.Millisecond
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, cooldown)
+
+	gomock.InOrder(
+		primary.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, errors.New("unavailable")),
+		secondary.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.30"), nil),
+		primary.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil),
+	)
+
+	_, err := client.GetExchangeRate(context.Background(), usdBRL)
+	require.NoError(t, err)
+	time.Sleep(2 * cooldown)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestFailoverClient_AllProvidersFail(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+
+	// Both are tried again on the second call, as no provider is healthy
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, errors.New("unauthorized")).
+		Times(2)
+	secondary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, errors.New("internal server error")).
+		Times(2)
+
+	// Act
+	_, firstErr := client.GetExchangeRate(context.Background(), usdBRL)
+	_, secondErr := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	for _, err := range []error{firstErr, secondErr} {
+		require.ErrorIs(t, err, exchangerate.ErrNoProviderAvailable)
+		assert.ErrorContains(t, err, "primary: unauthorized")
+		assert.ErrorContains(t, err, "secondary: internal server error")
+	}
+}
+
+func TestFailoverClient_FailsOverOnTimeout(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, 10*time.Millisecond, time.Minute)
+
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		DoAndReturn(func(ctx context.Context, request exchangerate.GetExchangeRateRequest) (*exchangerate.GetExchangeRateResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	secondary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(rateResponse("5.30"), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.3", response.Rate.String())
+}
+
+func TestFailoverClient_StopsWhenCallerGivesUp(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		DoAndReturn(func(ctx context.Context, request exchangerate.GetExchangeRateRequest) (*exchangerate.GetExchangeRateResponse, error) {
+			cancel()
+			return nil, ctx.Err()
+		})
+	primary.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(rateResponse("5.25"), nil) // Still healthy
+
+	// Act
+	_, cancelledErr := client.GetExchangeRate(ctx, usdBRL)
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.ErrorIs(t, cancelledErr, context.Canceled)
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestFailoverClient_GetExchangeRates_AsksNextProviderForMissingTargets(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+
+	primary.EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", []string{"BRL", "EUR"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.25")}, exchangerate.ErrUnknownCurrency)
+	secondary.EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", []string{"EUR"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"EUR": rateResponse("0.92")}, nil)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "5.25", rates["BRL"].Rate.String())
+	assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+}
+
+func TestFailoverClient_GetExchangeRates_AllProvidersFail(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+	}, time.Second, time.Minute)
+
+	primary.EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", []string{"BRL"}).
+		Return(nil, errors.New("quota exceeded"))
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+
+	// Assert
+	assert.Empty(t, rates)
+	assert.ErrorIs(t, err, exchangerate.ErrNoProviderAvailable)
+	assert.ErrorContains(t, err, "primary: quota exceeded")
+}
+
+func TestFailoverClient_GetHistoricalRates_AsksNextProviderForMissingTargets(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	primary.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(nil, errors.New("bad gateway"))
+	secondary.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("4.90")}, nil)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, date)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "4.9", rates["BRL"].Rate.String())
+}
+
+func TestFailoverClient_GetHistoricalRates_SkipsProvidersWithoutHistory(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	secondary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, time.Second, time.Minute)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	primary.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(nil, exchangerate.ErrHistoricalUnsupported)
+	secondary.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("4.90")}, nil)
+	// The primary isn't cooled down, so it still answers latest rates.
+	primary.EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", []string{"BRL"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.10")}, nil)
+
+	// Act
+	historical, historicalErr := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, date)
+	latest, latestErr := client.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+
+	// Assert
+	require.NoError(t, historicalErr)
+	assert.Equal(t, "4.9", historical["BRL"].Rate.String())
+	require.NoError(t, latestErr)
+	assert.Equal(t, "5.1", latest["BRL"].Rate.String())
+}
+
+func TestFailoverClient_GetHistoricalRates_NoProviderHasHistory(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewFailoverClient([]exchangerate.Provider{
+		{Name: "primary", Client: primary},
+	}, time.Second, time.Minute)
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	primary.EXPECT().
+		GetHistoricalRates(gomock.Any(), "USD", []string{"BRL"}, date).
+		Return(nil, exchangerate.ErrHistoricalUnsupported)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, date)
+
+	// Assert
+	assert.Empty(t, rates)
+	assert.ErrorIs(t, err, exchangerate.ErrHistoricalUnsupported)
+	assert.NotErrorIs(t, err, exchangerate.ErrNoProviderAvailable)
+}
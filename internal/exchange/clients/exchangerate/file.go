@@ -0,0 +1,219 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileRates maps a base currency to its rate to each target currency.
+type fileRates map[string]map[string]decimal.Decimal
+
+// fileClient serves pinned rates from a local file, for environments without
+// access to a provider. The file is read again whenever it changes on disk.
+//
+// YAML and JSON files map each base to its targets, like {"USD": {"BRL": 4.95}},
+// and CSV files have a base,target,rate row per pair, optionally under that header.
+type fileClient struct {
+	path string
+
+	mu      sync.Mutex
+	rates   fileRates
+	modTime time.Time
+	size    int64
+}
+
+func (f *fileClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	rates, err := f.GetExchangeRates(ctx, request.From, []string{request.To})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates[request.To], nil
+}
+
+// GetExchangeRates reads every target from the file. A pair missing from it is
+// answered with the inverse of the opposite pair when the file has that one.
+func (f *fileClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pinned, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		rate, ok := pinned.rate(base, target)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s-%s: %w: pair not in %s", base, target, ErrUnknownCurrency, f.path))
+			continue
+		}
+
+		rates[target] = &GetExchangeRateResponse{Rate: rate}
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+// GetHistoricalRates fails with ErrHistoricalUnsupported, as the file only has
+// today's pinned rates and recording them for past days would be wrong.
+func (f *fileClient) GetHistoricalRates(_ context.Context, _ string, _ []string, _ time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return nil, fmt.Errorf("%w: rates file %s has no dates", ErrHistoricalUnsupported, f.path)
+}
+
+// load returns the file's rates, reading it again when its modification time
+// or size changed since it was last read.
+func (f *fileClient) load() (fileRates, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	if f.rates != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.rates, nil
+	}
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+	defer file.Close()
+
+	rates, err := parseRatesFile(f.path, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rates file %s: %w", f.path, err)
+	}
+
+	f.rates, f.modTime, f.size = rates, info.ModTime(), info.Size()
+	return rates, nil
+}
+
+// rate returns the rate from base to target, or the inverse of the one from
+// target to base.
+func (r fileRates) rate(base, target string) (decimal.Decimal, bool) {
+	if rate, ok := r[base][target]; ok {
+		return rate, true
+	}
+
+	if inverse, ok := r[target][base]; ok && inverse.IsPositive() {
+		return decimal.NewFromInt(1).DivRound(inverse, aggregationPrecision), true
+	}
+
+	return decimal.Decimal{}, false
+}
+
+func parseRatesFile(path string, r io.Reader) (fileRates, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// Decoding into strings keeps the rates as written, without float rounding.
+		var raw map[string]map[string]string
+		if err := yaml.NewDecoder(r).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		return newFileRates(raw)
+	case ".json":
+		var raw map[string]map[string]json.Number
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		rates := make(map[string]map[string]string, len(raw))
+		for base, targets := range raw {
+			rates[base] = make(map[string]string, len(targets))
+			for target, rate := range targets {
+				rates[base][target] = rate.String()
+			}
+		}
+
+		return newFileRates(rates)
+	case ".csv":
+		return parseRatesCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported rates file extension %q, expected .yaml, .yml, .json or .csv", filepath.Ext(path))
+	}
+}
+
+func parseRatesCSV(r io.Reader) (fileRates, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > 0 && strings.EqualFold(records[0][0], "base") {
+		records = records[1:]
+	}
+
+	raw := map[string]map[string]string{}
+	for _, record := range records {
+		base, target := record[0], record[1]
+		if raw[base] == nil {
+			raw[base] = map[string]string{}
+		}
+
+		raw[base][target] = record[2]
+	}
+
+	return newFileRates(raw)
+}
+
+// newFileRates parses the rates, upper-casing the currencies.
+func newFileRates(raw map[string]map[string]string) (fileRates, error) {
+	rates := make(fileRates, len(raw))
+	for base, targets := range raw {
+		base = strings.ToUpper(strings.TrimSpace(base))
+		if rates[base] == nil {
+			rates[base] = make(map[string]decimal.Decimal, len(targets))
+		}
+
+		for target, value := range targets {
+			target = strings.ToUpper(strings.TrimSpace(target))
+			rate, err := decimal.NewFromString(strings.TrimSpace(value))
+			if err != nil || !rate.IsPositive() {
+				return nil, fmt.Errorf("invalid rate %q for %s-%s", value, base, target)
+			}
+
+			rates[base][target] = rate
+		}
+	}
+
+	return rates, nil
+}
+
+func (f *fileClient) batches() bool {
+	return true
+}
+
+// NewFileClient returns a Client serving the rates pinned in the YAML, JSON or
+// CSV file at path. The file is read right away, so a missing or malformed one
+// fails here rather than on the first call.
+func NewFileClient(path string) (Client, error) {
+	client := &fileClient{path: path}
+	if _, err := client.load(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
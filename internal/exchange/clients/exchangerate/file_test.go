@@ -0,0 +1,145 @@
+package exchangerate_test
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeRatesFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func newFileClient(t *testing.T, path string) exchangerate.Client {
+	client, err := exchangerate.NewFileClient(path)
+	require.NoError(t, err)
+	return client
+}
+
+func TestFileClient_GetExchangeRates_Formats(t *testing.T) {
+	tests := map[string]string{
+		"rates.yaml": "USD:\n  BRL: 4.951234567890123\n  eur: \"0.92\"\n",
+		"rates.json": `{"USD": {"BRL": 4.951234567890123, "EUR": "0.92"}}`,
+		"rates.csv":  "base,target,rate\nUSD,BRL,4.951234567890123\n# pinned on release\nUSD, EUR, 0.92\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			client := newFileClient(t, writeRatesFile(t, name, content))
+
+			// Act
+			rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, exchangerate.Batches(client))
+			assert.Equal(t, "4.951234567890123", rates["BRL"].Rate.String())
+			assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+		})
+	}
+}
+
+func TestFileClient_GetExchangeRates_InverseAndUnknownPairs(t *testing.T) {
+	// Arrange
+	path := writeRatesFile(t, "rates.yaml", "USD:\n  BRL: 5\n")
+	client := newFileClient(t, path)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "BRL", []string{"USD", "EUR"})
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, "BRL-EUR: unknown currency: pair not in "+path)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "0.2", rates["USD"].Rate.String())
+}
+
+func TestFileClient_GetExchangeRate_ReloadsChangedFile(t *testing.T) {
+	// Arrange
+	path := writeRatesFile(t, "rates.json", `{"USD": {"BRL": 4.9}}`)
+	client := newFileClient(t, path)
+	request := exchangerate.GetExchangeRateRequest{From: "USD", To: "BRL"}
+
+	first, err := client.GetExchangeRate(context.Background(), request)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"USD": {"BRL": 5.05}}`), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	// Act
+	second, err := client.GetExchangeRate(context.Background(), request)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "4.9", first.Rate.String())
+	assert.Equal(t, "5.05", second.Rate.String())
+}
+
+func TestFileClient_GetHistoricalRates_Unsupported(t *testing.T) {
+	// Arrange
+	client := newFileClient(t, writeRatesFile(t, "rates.csv", "USD,BRL,4.9\n"))
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Nil(t, rates)
+	assert.ErrorIs(t, err, exchangerate.ErrHistoricalUnsupported)
+}
+
+func TestNewFileClient_InvalidFile(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		content string
+		err     string
+	}{
+		"negative rate":     {name: "rates.yaml", content: "USD:\n  BRL: -1\n", err: `invalid rate "-1" for USD-BRL`},
+		"missing column":    {name: "rates.csv", content: "USD,BRL\n", err: "wrong number of fields"},
+		"unknown extension": {name: "rates.toml", content: "", err: `unsupported rates file extension ".toml"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			path := writeRatesFile(t, tt.name, tt.content)
+
+			// Act
+			client, err := exchangerate.NewFileClient(path)
+
+			// Assert
+			assert.Nil(t, client)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestNewFileClient_MissingFile(t *testing.T) {
+	// Act
+	_, err := exchangerate.NewFileClient(filepath.Join(t.TempDir(), "rates.yaml"))
+
+	// Assert
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileClient_GetExchangeRates_FileBrokenAfterStart(t *testing.T) {
+	// Arrange
+	path := writeRatesFile(t, "rates.yaml", "USD:\n  BRL: 5\n")
+	client := newFileClient(t, path)
+	require.NoError(t, os.Remove(path))
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+
+	// Assert
+	assert.Nil(t, rates)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -2,7 +2,11 @@ package exchangerate
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/jorgejr568/freecurrencyapi-go/v2"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
 type freeCurrencyApiClient struct {
@@ -18,11 +22,66 @@ func (f freeCurrencyApiClient) GetExchangeRate(ctx context.Context, request GetE
 		return nil, err
 	}
 
+	value, ok := rate.Rates[request.To]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, request.To)
+	}
+
 	return &GetExchangeRateResponse{
-		Rate: rate.Rates[request.To],
+		Rate: decimal.NewFromFloat(value),
 	}, nil
 }
 
+// GetExchangeRates gets every target in a single call.
+func (f freeCurrencyApiClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	latest, err := f.client.Latest(ctx, freecurrencyapi.LatestRequest{
+		BaseCurrency: base,
+		Currencies:   targets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ratesFor(base, targets, latest.Rates)
+}
+
+// GetHistoricalRates gets every target on date in a single call.
+func (f freeCurrencyApiClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	historical, err := f.client.Historical(ctx, freecurrencyapi.HistoricalRequest{
+		Date:         date.UTC(),
+		BaseCurrency: base,
+		Currencies:   targets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ratesFor(base, targets, historical.Rates)
+}
+
+// ratesFor picks the targets out of the values the API returned.
+func ratesFor(base string, targets []string, values map[string]float64) (map[string]*GetExchangeRateResponse, error) {
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		value, ok := values[target]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s-%s: %w", base, target, ErrUnknownCurrency))
+			continue
+		}
+
+		rates[target] = &GetExchangeRateResponse{
+			Rate: decimal.NewFromFloat(value),
+		}
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+func (f freeCurrencyApiClient) batches() bool {
+	return true
+}
+
 func NewFreeCurrencyApiClient(client freecurrencyapi.Client) Client {
 	return &freeCurrencyApiClient{
 		client: client,
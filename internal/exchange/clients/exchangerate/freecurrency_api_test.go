@@ -0,0 +1,111 @@
+package exchangerate_test
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/freecurrencyapi-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newFreeCurrencyApiClient(t *testing.T, handler http.HandlerFunc) exchangerate.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	httpClient := &http.Client{Transport: exchangerate.NewStatusTransport(http.DefaultTransport)}
+	return exchangerate.NewFreeCurrencyApiClient(freecurrencyapi.NewClient("key", freecurrencyapi.Options().
+		WithHTTPClient(httpClient).
+		WithBaseURL(server.URL+"/v1/")))
+}
+
+func TestFreeCurrencyApiClient_GetExchangeRate(t *testing.T) {
+	// Arrange
+	client := newFreeCurrencyApiClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/latest", r.URL.Path)
+		assert.Equal(t, "USD", r.URL.Query().Get("base_currency"))
+		assert.Equal(t, "BRL", r.URL.Query().Get("currencies"))
+		_, _ = w.Write([]byte(`{"data":{"BRL":5.25}}`))
+	})
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestFreeCurrencyApiClient_GetExchangeRate_UnknownCurrency(t *testing.T) {
+	// Arrange
+	client := newFreeCurrencyApiClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	})
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.False(t, exchangerate.IsRetryable(err))
+}
+
+func TestFreeCurrencyApiClient_GetExchangeRate_RateLimited(t *testing.T) {
+	// Arrange
+	client := newFreeCurrencyApiClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.True(t, exchangerate.IsRetryable(err))
+}
+
+func TestFreeCurrencyApiClient_GetExchangeRates(t *testing.T) {
+	// Arrange
+	calls := 0
+	client := newFreeCurrencyApiClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "BRL,EUR,XYZ", r.URL.Query().Get("currencies"))
+		_, _ = w.Write([]byte(`{"data":{"BRL":5.25,"EUR":0.92}}`))
+	})
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR", "XYZ"})
+
+	// Assert
+	assert.True(t, exchangerate.Batches(client))
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, "USD-XYZ")
+	require.Len(t, rates, 2)
+	assert.Equal(t, "5.25", rates["BRL"].Rate.String())
+	assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+}
+
+func TestFreeCurrencyApiClient_GetHistoricalRates(t *testing.T) {
+	// Arrange
+	client := newFreeCurrencyApiClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/historical", r.URL.Path)
+		assert.Equal(t, "2024-01-15", r.URL.Query().Get("date"))
+		assert.Equal(t, "USD", r.URL.Query().Get("base_currency"))
+		assert.Equal(t, "BRL,XYZ", r.URL.Query().Get("currencies"))
+		_, _ = w.Write([]byte(`{"data":{"2024-01-15":{"BRL":4.9}}}`))
+	})
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL", "XYZ"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, "USD-XYZ")
+	require.Len(t, rates, 1)
+	assert.Equal(t, "4.9", rates["BRL"].Rate.String())
+}
@@ -4,41 +4,188 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/shopspring/decimal"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// HTTPMapping describes a REST provider answering one pair per request.
+// {from}, {to} and {date} in Path, HistoricalPath and RatePath are replaced by
+// the pair's currencies and the day as YYYY-MM-DD.
+type HTTPMapping struct {
+	BaseURL string
+
+	// Path and HistoricalPath are appended to BaseURL for latest and historical
+	// rates. Without HistoricalPath, historical rates aren't supported.
+	Path           string
+	HistoricalPath string
+
+	// APIKey is sent in the AuthHeader header or the AuthQueryParam query
+	// parameter, whichever is set.
+	APIKey         string
+	AuthHeader     string
+	AuthQueryParam string
+
+	// RatePath is the dot-separated path to the rate in the JSON response, with
+	// numeric segments indexing arrays, like data.rates.{to} or quotes.0.price.
+	// The rate may be a JSON number or string.
+	RatePath string
+}
+
+// DefaultHTTPMapping is the mapping of APIs answering /convert?from=&to= with {"result": rate}.
+func DefaultHTTPMapping(baseURL string) HTTPMapping {
+	return HTTPMapping{
+		BaseURL:        baseURL,
+		Path:           "/convert?from={from}&to={to}",
+		HistoricalPath: "/convert?from={from}&to={to}&date={date}",
+		RatePath:       "result",
+	}
+}
+
 type httpClient struct {
 	http    *http.Client
-	baseUrl string
+	mapping HTTPMapping
 }
 
 func (h httpClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
-	url := fmt.Sprintf("%s/convert?from=%s&to=%s", h.baseUrl, request.From, request.To)
-	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return h.convert(ctx, h.mapping.Path, request, time.Time{})
+}
+
+// GetExchangeRates asks for one target at a time, as the API can't batch.
+func (h httpClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return getEachExchangeRate(ctx, base, targets, h.GetExchangeRate)
+}
+
+// GetHistoricalRates asks for one target at a time on HistoricalPath.
+func (h httpClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	if h.mapping.HistoricalPath == "" {
+		return nil, fmt.Errorf("%w: http provider has no historical path configured", ErrHistoricalUnsupported)
+	}
+
+	return getEachExchangeRate(ctx, base, targets, func(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+		return h.convert(ctx, h.mapping.HistoricalPath, request, date)
+	})
+}
+
+func (h httpClient) convert(ctx context.Context, path string, request GetExchangeRateRequest, date time.Time) (*GetExchangeRateResponse, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, h.mapping.BaseURL+expand(path, request, date, url.QueryEscape), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if h.mapping.APIKey != "" {
+		switch {
+		case h.mapping.AuthHeader != "":
+			httpRequest.Header.Set(h.mapping.AuthHeader, h.mapping.APIKey)
+		case h.mapping.AuthQueryParam != "":
+			query := httpRequest.URL.Query()
+			query.Set(h.mapping.AuthQueryParam, h.mapping.APIKey)
+			httpRequest.URL.RawQuery = query.Encode()
+		}
+	}
+
 	httpResponse, err := h.http.Do(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
+	defer httpResponse.Body.Close()
+
 	if httpResponse.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", httpResponse.StatusCode)
+		return nil, newStatusError(httpResponse)
 	}
 
-	var response GetExchangeRateResponse
-	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
-		return nil, err
+	decoder := json.NewDecoder(httpResponse.Body)
+	decoder.UseNumber()
+
+	var body any
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedResponse, err)
 	}
 
-	return &response, nil
+	rate, err := rateAt(body, h.mapping.RatePath, request, date)
+	if err != nil {
+		return nil, fmt.Errorf("%s-%s: %w", request.From, request.To, err)
+	}
+
+	return &GetExchangeRateResponse{Rate: rate}, nil
+}
+
+// expand replaces the placeholders of template, escaping their values.
+func expand(template string, request GetExchangeRateRequest, date time.Time, escape func(string) string) string {
+	day := ""
+	if !date.IsZero() {
+		day = date.UTC().Format(time.DateOnly)
+	}
+
+	return strings.NewReplacer(
+		"{from}", escape(request.From),
+		"{to}", escape(request.To),
+		"{date}", escape(day),
+	).Replace(template)
 }
 
+// rateAt walks the expanded RatePath template through a decoded JSON body down
+// to the rate. Only a missing segment naming a currency means the provider
+// doesn't know the pair; any other mismatch means the response is malformed.
+func rateAt(body any, template string, request GetExchangeRateRequest, date time.Time) (decimal.Decimal, error) {
+	path := expand(template, request, date, func(s string) string { return s })
+
+	value := body
+	for _, segment := range strings.Split(template, ".") {
+		key := expand(segment, request, date, func(s string) string { return s })
+
+		var ok bool
+		switch node := value.(type) {
+		case map[string]any:
+			value, ok = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			ok = err == nil && i >= 0 && i < len(node)
+			if ok {
+				value = node[i]
+			}
+		}
+
+		if !ok {
+			if strings.Contains(segment, "{from}") || strings.Contains(segment, "{to}") {
+				return decimal.Decimal{}, fmt.Errorf("%w: nothing at %q in the response", ErrUnknownCurrency, path)
+			}
+
+			return decimal.Decimal{}, fmt.Errorf("%w: nothing at %q", ErrMalformedResponse, path)
+		}
+	}
+
+	var rate decimal.Decimal
+	var err error
+	switch number := value.(type) {
+	case json.Number:
+		rate, err = decimal.NewFromString(number.String())
+	case string:
+		rate, err = decimal.NewFromString(number)
+	default:
+		return decimal.Decimal{}, fmt.Errorf("%w: rate at %q is a %T, not a number", ErrMalformedResponse, path, value)
+	}
+
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("%w: rate at %q: %w", ErrMalformedResponse, path, err)
+	}
+
+	return rate, nil
+}
+
+// NewHTTPClient returns a Client for APIs following DefaultHTTPMapping.
 func NewHTTPClient(http *http.Client, baseUrl string) Client {
+	return NewMappedHTTPClient(http, DefaultHTTPMapping(baseUrl))
+}
+
+// NewMappedHTTPClient returns a Client for the REST API described by mapping.
+func NewMappedHTTPClient(http *http.Client, mapping HTTPMapping) Client {
 	return &httpClient{
 		http:    http,
-		baseUrl: baseUrl,
+		mapping: mapping,
 	}
 }
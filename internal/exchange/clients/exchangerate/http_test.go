@@ -0,0 +1,171 @@
+package exchangerate_test
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_GetExchangeRates_AsksOneTargetAtATime(t *testing.T) {
+	// Arrange
+	var targets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/convert", r.URL.Path)
+		assert.Equal(t, "USD", r.URL.Query().Get("from"))
+		to := r.URL.Query().Get("to")
+		targets = append(targets, to)
+		if to == "XYZ" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"result":5.25}`))
+	}))
+	defer server.Close()
+
+	client := exchangerate.NewHTTPClient(server.Client(), server.URL)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "XYZ", "EUR"})
+
+	// Assert
+	assert.False(t, exchangerate.Batches(client))
+	assert.Equal(t, []string{"BRL", "XYZ", "EUR"}, targets)
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "5.25", rates["BRL"].Rate.String())
+	assert.Contains(t, rates, "EUR")
+}
+
+func TestHTTPClient_GetHistoricalRates_PassesDate(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/convert", r.URL.Path)
+		assert.Equal(t, "USD", r.URL.Query().Get("from"))
+		assert.Equal(t, "BRL", r.URL.Query().Get("to"))
+		assert.Equal(t, "2024-01-15", r.URL.Query().Get("date"))
+		_, _ = w.Write([]byte(`{"result":4.9}`))
+	}))
+	defer server.Close()
+
+	client := exchangerate.NewHTTPClient(server.Client(), server.URL)
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "4.9", rates["BRL"].Rate.String())
+}
+
+func TestMappedHTTPClient_GetExchangeRate_HeaderAuthAndNestedRate(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/rates/USD", r.URL.Path)
+		assert.Equal(t, "BRL", r.URL.Query().Get("symbols"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data":{"quotes":[{"rates":{"BRL":"5.123456789012345"}}]}}`))
+	}))
+	defer server.Close()
+
+	client := exchangerate.NewMappedHTTPClient(server.Client(), exchangerate.HTTPMapping{
+		BaseURL:    server.URL,
+		Path:       "/v2/rates/{from}?symbols={to}",
+		APIKey:     "Bearer secret",
+		AuthHeader: "Authorization",
+		RatePath:   "data.quotes.0.rates.{to}",
+	})
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.123456789012345", response.Rate.String()) // No float rounding
+}
+
+func TestMappedHTTPClient_GetHistoricalRates_QueryAuth(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/history/2024-01-15", r.URL.Path)
+		assert.Equal(t, "USD", r.URL.Query().Get("base"))
+		assert.Equal(t, "secret", r.URL.Query().Get("apikey"))
+		_, _ = w.Write([]byte(`{"rates":{"BRL":4.9}}`))
+	}))
+	defer server.Close()
+
+	client := exchangerate.NewMappedHTTPClient(server.Client(), exchangerate.HTTPMapping{
+		BaseURL:        server.URL,
+		Path:           "/latest?base={from}",
+		HistoricalPath: "/history/{date}?base={from}",
+		APIKey:         "secret",
+		AuthQueryParam: "apikey",
+		RatePath:       "rates.{to}",
+	})
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL", "XYZ"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, `USD-XYZ: unknown currency: nothing at "rates.XYZ" in the response`)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "4.9", rates["BRL"].Rate.String())
+}
+
+func TestMappedHTTPClient_GetHistoricalRates_NotConfigured(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewMappedHTTPClient(http.DefaultClient, exchangerate.HTTPMapping{
+		BaseURL:  "http://localhost",
+		Path:     "/latest?base={from}",
+		RatePath: "rates.{to}",
+	})
+
+	// Act
+	rates, err := client.GetHistoricalRates(context.Background(), "USD", []string{"BRL"}, time.Now())
+
+	// Assert
+	assert.Nil(t, rates)
+	assert.ErrorIs(t, err, exchangerate.ErrHistoricalUnsupported)
+}
+
+func TestMappedHTTPClient_GetExchangeRate_MalformedResponse(t *testing.T) {
+	tests := map[string]string{
+		"missing object": `{"error": "invalid api key"}`,
+		"not a number":   `{"rates": {"BRL": true}}`,
+		"invalid number": `{"rates": {"BRL": "n/a"}}`,
+		"not json":       `<html>maintenance</html>`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := exchangerate.NewMappedHTTPClient(server.Client(), exchangerate.HTTPMapping{
+				BaseURL:  server.URL,
+				Path:     "/latest?base={from}",
+				RatePath: "rates.{to}",
+			})
+
+			// Act
+			response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+			// Assert
+			assert.Nil(t, response)
+			assert.ErrorIs(t, err, exchangerate.ErrMalformedResponse)
+			assert.NotErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+		})
+	}
+}
@@ -1,10 +1,43 @@
 package exchangerate
 
-//go:generate mockgen -destination=mocks/mock_client.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate Client
+//go:generate mockgen -destination=mocks/mock_client.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate Client,ProviderStatusReporter
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Client interface {
 	// GetExchangeRate returns the exchange rate between two currencies.
 	GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error)
+
+	// GetExchangeRates returns the exchange rates from base to each target, by
+	// target. Targets it couldn't get are left out and described by the error,
+	// so the rates may be partial even when the error isn't nil.
+	GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error)
+
+	// GetHistoricalRates returns the exchange rates from base to each target on
+	// the given UTC day, like GetExchangeRates.
+	GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error)
+}
+
+// BreakerClient is a Client guarded by a circuit breaker.
+type BreakerClient interface {
+	Client
+
+	// BreakerStatus returns the current state of the breaker.
+	BreakerStatus() BreakerStatus
+}
+
+// RateLimitedClient is a Client keeping within a provider's quotas.
+type RateLimitedClient interface {
+	Client
+
+	// QuotaStatus returns how much of its monthly quota the provider has used.
+	QuotaStatus(ctx context.Context) (QuotaStatus, error)
+}
+
+// ProviderStatusReporter reports the state of the exchange rate providers.
+type ProviderStatusReporter interface {
+	ProviderStatuses() []BreakerStatus
 }
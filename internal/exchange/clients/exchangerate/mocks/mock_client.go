@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate (interfaces: Client)
+// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate (interfaces: Client,ProviderStatusReporter)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mock_client.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate Client
+//	mockgen -destination=mocks/mock_client.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate Client,ProviderStatusReporter
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	exchangerate "github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
 	gomock "go.uber.org/mock/gomock"
@@ -55,3 +56,71 @@ func (mr *MockClientMockRecorder) GetExchangeRate(ctx, request any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockClient)(nil).GetExchangeRate), ctx, request)
 }
+
+// GetExchangeRates mocks base method.
+func (m *MockClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*exchangerate.GetExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx, base, targets)
+	ret0, _ := ret[0].(map[string]*exchangerate.GetExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockClientMockRecorder) GetExchangeRates(ctx, base, targets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockClient)(nil).GetExchangeRates), ctx, base, targets)
+}
+
+// GetHistoricalRates mocks base method.
+func (m *MockClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*exchangerate.GetExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalRates", ctx, base, targets, date)
+	ret0, _ := ret[0].(map[string]*exchangerate.GetExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalRates indicates an expected call of GetHistoricalRates.
+func (mr *MockClientMockRecorder) GetHistoricalRates(ctx, base, targets, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalRates", reflect.TypeOf((*MockClient)(nil).GetHistoricalRates), ctx, base, targets, date)
+}
+
+// MockProviderStatusReporter is a mock of ProviderStatusReporter interface.
+type MockProviderStatusReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderStatusReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockProviderStatusReporterMockRecorder is the mock recorder for MockProviderStatusReporter.
+type MockProviderStatusReporterMockRecorder struct {
+	mock *MockProviderStatusReporter
+}
+
+// NewMockProviderStatusReporter creates a new mock instance.
+func NewMockProviderStatusReporter(ctrl *gomock.Controller) *MockProviderStatusReporter {
+	mock := &MockProviderStatusReporter{ctrl: ctrl}
+	mock.recorder = &MockProviderStatusReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderStatusReporter) EXPECT() *MockProviderStatusReporterMockRecorder {
+	return m.recorder
+}
+
+// ProviderStatuses mocks base method.
+func (m *MockProviderStatusReporter) ProviderStatuses() []exchangerate.BreakerStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderStatuses")
+	ret0, _ := ret[0].([]exchangerate.BreakerStatus)
+	return ret0
+}
+
+// ProviderStatuses indicates an expected call of ProviderStatuses.
+func (mr *MockProviderStatusReporterMockRecorder) ProviderStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderStatuses", reflect.TypeOf((*MockProviderStatusReporter)(nil).ProviderStatuses))
+}
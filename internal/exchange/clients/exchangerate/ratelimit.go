@@ -0,0 +1,218 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned without calling the provider once its monthly quota is used up.
+var ErrQuotaExhausted = errors.New("exchange rate provider monthly quota exhausted")
+
+// QuotaStatus is how much of its monthly quota a provider has used. A zero
+// Limit means the provider has no monthly quota.
+type QuotaStatus struct {
+	Provider string `json:"provider" example:"freecurrencyapi"`
+	Used     int64  `json:"used"`
+	Limit    int64  `json:"limit"`
+}
+
+// Remaining returns the calls left this month, or -1 when there is no quota.
+func (q QuotaStatus) Remaining() int64 {
+	if q.Limit <= 0 {
+		return -1
+	}
+
+	return max(q.Limit-q.Used, 0)
+}
+
+func (q QuotaStatus) Exhausted() bool {
+	return q.Remaining() == 0
+}
+
+// tokenBucket holds up to capacity tokens, refilled evenly over a minute.
+type tokenBucket struct {
+	capacity  float64
+	perSecond float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perMinute int) *tokenBucket {
+	return &tokenBucket{
+		capacity:  float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+		tokens:    float64(perMinute),
+		last:      time.Now(),
+	}
+}
+
+// wait takes a token, waiting for one to be refilled when the bucket is empty.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// take takes a token and returns zero, or returns how long until one is refilled.
+func (b *tokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.perSecond)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+}
+
+// rateLimitedClient keeps calls to a provider within a per-minute token bucket
+// and a monthly quota, counting every call in usage.
+type rateLimitedClient struct {
+	provider Provider
+	bucket   *tokenBucket
+	perMonth int64
+	usage    entity.ProviderUsageService
+}
+
+func (r *rateLimitedClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	if err := r.acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return r.provider.Client.GetExchangeRate(ctx, request)
+}
+
+// GetExchangeRates counts a single call for providers that batch and one per
+// target for the others.
+func (r *rateLimitedClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return r.getRates(ctx, base, targets, latestRates)
+}
+
+// GetHistoricalRates counts calls like GetExchangeRates.
+func (r *rateLimitedClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return r.getRates(ctx, base, targets, historicalRates(date))
+}
+
+func (r *rateLimitedClient) getRates(ctx context.Context, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	calls := int64(len(targets))
+	if Batches(r.provider.Client) {
+		calls = 1
+	}
+
+	if err := r.acquire(ctx, calls); err != nil {
+		return nil, err
+	}
+
+	return fetch(ctx, r.provider.Client, base, targets)
+}
+
+func (r *rateLimitedClient) QuotaStatus(ctx context.Context) (QuotaStatus, error) {
+	used, err := r.usage.CountProviderCalls(ctx, r.provider.Name, time.Now())
+	if err != nil {
+		return QuotaStatus{}, err
+	}
+
+	return QuotaStatus{Provider: r.provider.Name, Used: used, Limit: r.perMonth}, nil
+}
+
+// acquire checks that the monthly quota has room for calls, waits for a token
+// per call and records the calls, which count against the quota whether or not
+// they succeed. The quota is checked before the calls are recorded, so only
+// concurrent calls may overshoot it, by a few, which providers tolerate.
+func (r *rateLimitedClient) acquire(ctx context.Context, calls int64) error {
+	status, err := r.QuotaStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Limit > 0 && calls > status.Remaining() {
+		return fmt.Errorf("%w: %s used %d of %d calls, %d more needed", ErrQuotaExhausted, r.provider.Name, status.Used, status.Limit, calls)
+	}
+
+	if r.bucket != nil {
+		for range calls {
+			if err := r.bucket.wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.usage.RecordProviderCalls(ctx, r.provider.Name, time.Now(), calls)
+}
+
+// NewRateLimitedClient limits calls to provider to perMinute, with bursts of as
+// many, and perMonth in each calendar month (UTC). Zero disables either limit.
+func NewRateLimitedClient(provider Provider, perMinute int, perMonth int64, usage entity.ProviderUsageService) RateLimitedClient {
+	var bucket *tokenBucket
+	if perMinute > 0 {
+		bucket = newTokenBucket(perMinute)
+	}
+
+	return &rateLimitedClient{
+		provider: provider,
+		bucket:   bucket,
+		perMonth: perMonth,
+		usage:    usage,
+	}
+}
+
+// RateLimiters reports the monthly quotas of several providers, in order.
+type RateLimiters []RateLimitedClient
+
+func (r RateLimiters) QuotaStatuses(ctx context.Context) ([]QuotaStatus, error) {
+	statuses := make([]QuotaStatus, 0, len(r))
+	for _, limiter := range r {
+		status, err := limiter.QuotaStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// CheckBudget returns ErrQuotaExhausted when every provider has used up its
+// monthly quota, so a sync can be refused before calling any of them.
+func (r RateLimiters) CheckBudget(ctx context.Context) error {
+	if len(r) == 0 {
+		return nil
+	}
+
+	statuses, err := r.QuotaStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	exhausted := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		if !status.Exhausted() {
+			return nil
+		}
+
+		exhausted = append(exhausted, fmt.Sprintf("%s used %d of %d calls", status.Provider, status.Used, status.Limit))
+	}
+
+	return fmt.Errorf("%w: %s", ErrQuotaExhausted, strings.Join(exhausted, ", "))
+}
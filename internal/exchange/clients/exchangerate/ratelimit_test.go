@@ -0,0 +1,137 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	entityMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedClient_RecordsCalls(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	usage := exchange.NewInMemoryProviderUsageService()
+	client := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "primary", Client: inner}, 0, 100, usage)
+
+	inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+	inner.EXPECT().
+		GetExchangeRates(gomock.Any(), "USD", []string{"BRL", "EUR"}).
+		Return(nil, errors.New("bad gateway"))
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+	_, batchErr := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.EqualError(t, batchErr, "bad gateway")
+
+	status, err := client.QuotaStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, exchangerate.QuotaStatus{Provider: "primary", Used: 3, Limit: 100}, status) // The mock can't batch
+	assert.Equal(t, int64(97), status.Remaining())
+}
+
+func TestRateLimitedClient_QuotaExhausted(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	usage := entityMocks.NewMockProviderUsageService(ctrl)
+	client := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "primary", Client: inner}, 0, 100, usage)
+
+	usage.EXPECT().CountProviderCalls(gomock.Any(), "primary", gomock.Any()).Return(int64(100), nil)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, exchangerate.ErrQuotaExhausted)
+	assert.ErrorContains(t, err, "primary used 100 of 100 calls")
+}
+
+func TestRateLimitedClient_RefusesBatchesLargerThanTheQuotaLeft(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl) // The mock can't batch
+	usage := exchange.NewInMemoryProviderUsageService()
+	client := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "primary", Client: inner}, 0, 3, usage)
+	require.NoError(t, usage.RecordProviderCalls(context.Background(), "primary", time.Now(), 2))
+
+	inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+
+	// Act
+	rates, batchErr := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+	_, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, rates)
+	assert.ErrorIs(t, batchErr, exchangerate.ErrQuotaExhausted)
+	assert.ErrorContains(t, batchErr, "primary used 2 of 3 calls, 2 more needed")
+	require.NoError(t, err)
+
+	status, err := client.QuotaStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), status.Used)
+}
+
+func TestRateLimitedClient_WaitsForToken(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "primary", Client: inner}, 1, 0, exchange.NewInMemoryProviderUsageService())
+
+	inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil)
+
+	_, err := client.GetExchangeRate(context.Background(), usdBRL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err = client.GetExchangeRate(ctx, usdBRL)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiters_CheckBudget(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usage := exchange.NewInMemoryProviderUsageService()
+	require.NoError(t, usage.RecordProviderCalls(context.Background(), "primary", time.Now(), 10))
+	require.NoError(t, usage.RecordProviderCalls(context.Background(), "secondary", time.Now(), 5))
+
+	primary := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "primary", Client: mocks.NewMockClient(ctrl)}, 0, 10, usage)
+	secondary := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "secondary", Client: mocks.NewMockClient(ctrl)}, 0, 5, usage)
+	unlimited := exchangerate.NewRateLimitedClient(exchangerate.Provider{Name: "unlimited", Client: mocks.NewMockClient(ctrl)}, 0, 0, usage)
+
+	// Act
+	exhaustedErr := exchangerate.RateLimiters{primary, secondary}.CheckBudget(context.Background())
+	unlimitedErr := exchangerate.RateLimiters{primary, unlimited}.CheckBudget(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, exhaustedErr, exchangerate.ErrQuotaExhausted)
+	assert.ErrorContains(t, exhaustedErr, "primary used 10 of 10 calls, secondary used 5 of 5 calls")
+	assert.NoError(t, unlimitedErr)
+}
@@ -0,0 +1,121 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"math/rand/v2"
+	"time"
+)
+
+// retryingClient retries retryable failures with exponential backoff and full
+// jitter. A provider's Retry-After is honoured instead of the backoff, and the
+// failure returned as is when it asks for more than maxBackoff.
+type retryingClient struct {
+	client     Client
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func (r *retryingClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := r.client.GetExchangeRate(ctx, request)
+		if err == nil || attempt >= r.attempts || !IsRetryable(err) || ctx.Err() != nil {
+			return response, err
+		}
+
+		delay, ok := r.delay(attempt, err)
+		if !ok {
+			return response, err
+		}
+
+		log.Warn().Err(err).Msgf("exchange rate for %s-%s failed on attempt %d of %d, retrying in %s", request.From, request.To, attempt, r.attempts, delay)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// GetExchangeRates retries the targets that failed retryably, keeping the
+// rates got along the way.
+func (r *retryingClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return r.getRates(ctx, base, targets, latestRates)
+}
+
+// GetHistoricalRates retries like GetExchangeRates.
+func (r *retryingClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return r.getRates(ctx, base, targets, historicalRates(date))
+}
+
+func (r *retryingClient) getRates(ctx context.Context, base string, targets []string, fetch fetchRates) (map[string]*GetExchangeRateResponse, error) {
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	missing := targets
+	for attempt := 1; ; attempt++ {
+		got, err := fetch(ctx, r.client, base, missing)
+		for target, response := range got {
+			rates[target] = response
+		}
+
+		missing = missingTargets(missing, rates)
+		if err == nil || len(missing) == 0 || attempt >= r.attempts || !IsRetryable(err) || ctx.Err() != nil {
+			return rates, err
+		}
+
+		delay, ok := r.delay(attempt, err)
+		if !ok {
+			return rates, err
+		}
+
+		log.Warn().Err(err).Msgf("exchange rates for %s failed on attempt %d of %d, retrying %d targets in %s", base, attempt, r.attempts, len(missing), delay)
+		select {
+		case <-ctx.Done():
+			return rates, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// delay is a random duration up to backoff doubled for every attempt so far,
+// capped at maxBackoff, unless the provider said how long to wait. It reports
+// false when the provider asked for longer than maxBackoff, which isn't worth
+// waiting for.
+func (r *retryingClient) delay(attempt int, err error) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= r.maxBackoff
+	}
+
+	backoff := r.maxBackoff
+	if shift := attempt - 1; shift < 32 && r.backoff<<shift < r.maxBackoff {
+		backoff = r.backoff << shift
+	}
+
+	if backoff <= 0 {
+		return 0, true
+	}
+
+	return rand.N(backoff) + 1, true
+}
+
+// NewRetryingClient returns a Client making up to attempts calls to client. It
+// waits up to backoff before the first retry, twice that before the next, and
+// so on up to maxBackoff.
+func NewRetryingClient(client Client, attempts int, backoff, maxBackoff time.Duration) Client {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	if maxBackoff < backoff {
+		maxBackoff = backoff
+	}
+
+	return &retryingClient{
+		client:     client,
+		attempts:   attempts,
+		backoff:    backoff,
+		maxBackoff: maxBackoff,
+	}
+}
@@ -0,0 +1,223 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryingClient_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 3, time.Millisecond, 10*time.Millisecond)
+
+	gomock.InOrder(
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, &exchangerate.StatusError{StatusCode: http.StatusBadGateway}),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, context.DeadlineExceeded),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil),
+	)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestRetryingClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 2, time.Millisecond, time.Millisecond)
+	expectedError := &exchangerate.StatusError{StatusCode: http.StatusServiceUnavailable}
+
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, expectedError).
+		Times(2)
+
+	// Act
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestRetryingClient_DoesNotRetryPermanentErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"bad request", &exchangerate.StatusError{StatusCode: http.StatusUnprocessableEntity}},
+		{"unknown currency", exchangerate.ErrUnknownCurrency},
+		{"unrecognised", errors.New("invalid character '<' looking for beginning of value")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := mocks.NewMockClient(ctrl)
+			client := exchangerate.NewRetryingClient(inner, 3, time.Millisecond, time.Millisecond)
+
+			inner.EXPECT().
+				GetExchangeRate(gomock.Any(), usdBRL).
+				Return(nil, tc.err).
+				Times(1)
+
+			// Act
+			response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+			// Assert
+			assert.Nil(t, response)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestRetryingClient_HonoursRetryAfter(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 2, time.Hour, time.Hour)
+
+	gomock.InOrder(
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, &exchangerate.StatusError{
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: 20 * time.Millisecond,
+		}),
+		inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(rateResponse("5.25"), nil),
+	)
+
+	// Act
+	start := time.Now()
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.25", response.Rate.String())
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Minute) // Retry-After wins over the hour-long backoff
+}
+
+func TestRetryingClient_GivesUpWhenRetryAfterExceedsMaxBackoff(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 3, time.Millisecond, time.Second)
+
+	inner.EXPECT().GetExchangeRate(gomock.Any(), usdBRL).Return(nil, &exchangerate.StatusError{
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: time.Hour,
+	})
+
+	// Act
+	start := time.Now()
+	response, err := client.GetExchangeRate(context.Background(), usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryingClient_StopsWhenContextIsDone(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 3, time.Hour, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	inner.EXPECT().
+		GetExchangeRate(gomock.Any(), usdBRL).
+		Return(nil, &exchangerate.StatusError{StatusCode: http.StatusInternalServerError})
+
+	// Act
+	response, err := client.GetExchangeRate(ctx, usdBRL)
+
+	// Assert
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryingClient_GetExchangeRates_RetriesMissingTargets(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 3, time.Millisecond, time.Millisecond)
+
+	gomock.InOrder(
+		inner.EXPECT().
+			GetExchangeRates(gomock.Any(), "USD", []string{"BRL", "EUR"}).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.25")}, &exchangerate.StatusError{StatusCode: http.StatusBadGateway}),
+		inner.EXPECT().
+			GetExchangeRates(gomock.Any(), "USD", []string{"EUR"}).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{"EUR": rateResponse("0.92")}, nil),
+	)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+}
+
+func TestRetryingClient_GetExchangeRates_RetriesPartlyRetryableBatches(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockClient(ctrl)
+	client := exchangerate.NewRetryingClient(inner, 2, time.Millisecond, time.Millisecond)
+
+	gomock.InOrder(
+		inner.EXPECT().
+			GetExchangeRates(gomock.Any(), "USD", []string{"BRL", "EUR", "XYZ"}).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": rateResponse("5.25")}, errors.Join(
+				fmt.Errorf("USD-EUR: %w", &exchangerate.StatusError{StatusCode: http.StatusBadGateway}),
+				fmt.Errorf("USD-XYZ: %w", exchangerate.ErrUnknownCurrency),
+			)),
+		inner.EXPECT().
+			GetExchangeRates(gomock.Any(), "USD", []string{"EUR", "XYZ"}).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{"EUR": rateResponse("0.92")}, fmt.Errorf("USD-XYZ: %w", exchangerate.ErrUnknownCurrency)),
+	)
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR", "XYZ"})
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+}
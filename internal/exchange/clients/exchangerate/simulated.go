@@ -0,0 +1,194 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"hash/fnv"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const (
+	// year is the time unit of RandomWalk's drift and volatility.
+	year = 365 * 24 * time.Hour
+
+	// simulatedPrecision is the number of decimal places of simulated rates,
+	// hiding float rounding.
+	simulatedPrecision = 10
+
+	// checkpointInterval is how many steps apart simulatedClient keeps the log
+	// rates it walked through, to walk on from.
+	checkpointInterval = 1024
+)
+
+// RandomWalk describes the rates of a simulated provider. Each currency's rate
+// from Anchor follows its own geometric random walk, moving every Step from
+// Rates at Start, so rates between any two currencies are their cross rate.
+type RandomWalk struct {
+	// Seed picks the walk. The same seed, rates and start always give the same rates.
+	Seed   uint64
+	Anchor string
+
+	// Rates are the rates from Anchor to each currency at Start.
+	Rates map[string]decimal.Decimal
+
+	// Drift and Volatility are the annualised mean and standard deviation of
+	// the rates' log returns.
+	Drift      float64
+	Volatility float64
+
+	Step  time.Duration
+	Start time.Time
+}
+
+// simulatedClient generates rates from a RandomWalk, for demos and load tests
+// that shouldn't depend on a real provider.
+type simulatedClient struct {
+	walk RandomWalk
+
+	// mu guards the log rates of every checkpointInterval-th step walked
+	// through, by step, starting with the rates at Start.
+	mu          sync.Mutex
+	checkpoints map[int64]map[string]float64
+}
+
+func (s *simulatedClient) GetExchangeRate(ctx context.Context, request GetExchangeRateRequest) (*GetExchangeRateResponse, error) {
+	rates, err := s.GetExchangeRates(ctx, request.From, []string{request.To})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates[request.To], nil
+}
+
+// GetExchangeRates returns the rates of the current step.
+func (s *simulatedClient) GetExchangeRates(ctx context.Context, base string, targets []string) (map[string]*GetExchangeRateResponse, error) {
+	return s.ratesAt(ctx, base, targets, time.Now())
+}
+
+// GetHistoricalRates returns the rates of the step date falls in, walking
+// backwards from Start for dates before it.
+func (s *simulatedClient) GetHistoricalRates(ctx context.Context, base string, targets []string, date time.Time) (map[string]*GetExchangeRateResponse, error) {
+	return s.ratesAt(ctx, base, targets, date)
+}
+
+func (s *simulatedClient) ratesAt(ctx context.Context, base string, targets []string, at time.Time) (map[string]*GetExchangeRateResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	logRates := s.walkTo(s.stepAt(at))
+
+	baseLogRate, ok := logRates[base]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, base)
+	}
+
+	rates := make(map[string]*GetExchangeRateResponse, len(targets))
+	var errs []error
+	for _, target := range targets {
+		targetLogRate, ok := logRates[target]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s-%s: %w", base, target, ErrUnknownCurrency))
+			continue
+		}
+
+		rates[target] = &GetExchangeRateResponse{Rate: decimal.NewFromFloat(math.Exp(targetLogRate - baseLogRate)).Round(simulatedPrecision)}
+	}
+
+	return rates, errors.Join(errs...)
+}
+
+// stepAt returns the step at falls in, negative before Start.
+func (s *simulatedClient) stepAt(at time.Time) int64 {
+	elapsed := at.Sub(s.walk.Start)
+	step := int64(elapsed / s.walk.Step)
+	if elapsed%s.walk.Step < 0 {
+		step--
+	}
+
+	return step
+}
+
+// walkTo returns the log rates at step. It walks away from Start to step from
+// the closest checkpoint before it, keeping the checkpoints it passes, so that
+// a backfill doesn't replay the walk for every day and a step's rates, always
+// summed in the same order, never depend on the calls made before.
+func (s *simulatedClient) walkTo(step int64) map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	direction := int64(1)
+	if step < 0 {
+		direction = -1
+	}
+
+	// Integer division truncates towards zero, so towards Start.
+	at := step / checkpointInterval * checkpointInterval
+	for s.checkpoints[at] == nil {
+		at -= direction * checkpointInterval
+	}
+
+	logRates := maps.Clone(s.checkpoints[at])
+	for at != step {
+		if direction > 0 {
+			at++
+			for currency := range logRates {
+				logRates[currency] += s.increment(currency, at)
+			}
+		} else {
+			for currency := range logRates {
+				logRates[currency] -= s.increment(currency, at)
+			}
+			at--
+		}
+
+		if at%checkpointInterval == 0 {
+			s.checkpoints[at] = maps.Clone(logRates)
+		}
+	}
+
+	logRates[s.walk.Anchor] = 0
+	return logRates
+}
+
+// increment returns the change of currency's log rate on step, drawn from a
+// generator seeded by the walk's seed, the currency and the step alone.
+func (s *simulatedClient) increment(currency string, step int64) float64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(currency))
+
+	dt := float64(s.walk.Step) / float64(year)
+	z := rand.New(rand.NewPCG(s.walk.Seed^hash.Sum64(), uint64(step))).NormFloat64()
+
+	return (s.walk.Drift-s.walk.Volatility*s.walk.Volatility/2)*dt + s.walk.Volatility*math.Sqrt(dt)*z
+}
+
+func (s *simulatedClient) batches() bool {
+	return true
+}
+
+// NewSimulatedClient returns a Client generating rates from walk. Without a
+// Start, the walk starts at the current step.
+func NewSimulatedClient(walk RandomWalk) Client {
+	if walk.Start.IsZero() {
+		walk.Start = time.Now().Truncate(walk.Step)
+	}
+
+	logRates := make(map[string]float64, len(walk.Rates))
+	for currency, rate := range walk.Rates {
+		if currency != walk.Anchor {
+			logRates[currency] = math.Log(rate.InexactFloat64())
+		}
+	}
+
+	return &simulatedClient{
+		walk:        walk,
+		checkpoints: map[int64]map[string]float64{0: logRates},
+	}
+}
@@ -0,0 +1,141 @@
+package exchangerate_test
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var walkStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newRandomWalk(seed uint64) exchangerate.RandomWalk {
+	return exchangerate.RandomWalk{
+		Seed:   seed,
+		Anchor: "USD",
+		Rates: map[string]decimal.Decimal{
+			"BRL": decimal.RequireFromString("5"),
+			"EUR": decimal.RequireFromString("0.92"),
+		},
+		Volatility: 0.2,
+		Step:       time.Hour,
+		Start:      walkStart,
+	}
+}
+
+func historicalRates(t *testing.T, client exchangerate.Client, base string, targets []string, date time.Time) map[string]decimal.Decimal {
+	rates, err := client.GetHistoricalRates(context.Background(), base, targets, date)
+	require.NoError(t, err)
+
+	values := make(map[string]decimal.Decimal, len(rates))
+	for target, rate := range rates {
+		values[target] = rate.Rate
+	}
+
+	return values
+}
+
+func TestSimulatedClient_GetHistoricalRates_StartsAtSeedRates(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewSimulatedClient(newRandomWalk(1))
+
+	// Act
+	rates := historicalRates(t, client, "USD", []string{"BRL", "EUR"}, walkStart.Add(59*time.Minute))
+
+	// Assert
+	assert.True(t, exchangerate.Batches(client))
+	assert.Equal(t, "5", rates["BRL"].String())
+	assert.Equal(t, "0.92", rates["EUR"].String())
+}
+
+func TestSimulatedClient_GetHistoricalRates_Deterministic(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewSimulatedClient(newRandomWalk(1))
+	later := walkStart.AddDate(0, 0, 30)
+	earlier := walkStart.AddDate(0, 0, -10)
+
+	// Act
+	// Walk past the dates and back, so they're reached from other positions.
+	historicalRates(t, client, "USD", []string{"BRL"}, walkStart.AddDate(0, 0, 60))
+	atLater := historicalRates(t, client, "USD", []string{"BRL"}, later)
+	historicalRates(t, client, "USD", []string{"BRL"}, walkStart.AddDate(0, 0, -20))
+	atEarlier := historicalRates(t, client, "USD", []string{"BRL"}, earlier)
+
+	// Assert
+	fresh := exchangerate.NewSimulatedClient(newRandomWalk(1))
+	assert.Equal(t, historicalRates(t, fresh, "USD", []string{"BRL"}, later), atLater)
+	assert.Equal(t, historicalRates(t, fresh, "USD", []string{"BRL"}, earlier), atEarlier)
+	assert.False(t, atLater["BRL"].Equal(decimal.RequireFromString("5")))
+
+	otherSeed := exchangerate.NewSimulatedClient(newRandomWalk(2))
+	assert.NotEqual(t, historicalRates(t, otherSeed, "USD", []string{"BRL"}, later), atLater)
+}
+
+func TestSimulatedClient_GetHistoricalRates_BackfillMatchesFreshClients(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewSimulatedClient(newRandomWalk(1))
+	from := walkStart.AddDate(0, 0, -100)
+
+	// Act
+	// Days before Start are walked towards it, like a backfill does, crossing
+	// checkpoints on the way.
+	var backfilled []map[string]decimal.Decimal
+	for day := from; day.Before(walkStart); day = day.AddDate(0, 0, 1) {
+		backfilled = append(backfilled, historicalRates(t, client, "USD", []string{"BRL", "EUR"}, day))
+	}
+
+	// Assert
+	for i, rates := range backfilled {
+		fresh := exchangerate.NewSimulatedClient(newRandomWalk(1))
+		assert.Equal(t, historicalRates(t, fresh, "USD", []string{"BRL", "EUR"}, from.AddDate(0, 0, i)), rates)
+	}
+}
+
+func TestSimulatedClient_GetHistoricalRates_InverseAndCrossRatesAgree(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewSimulatedClient(newRandomWalk(1))
+	date := walkStart.AddDate(0, 2, 0)
+	tolerance := decimal.RequireFromString("0.000000001")
+
+	// Act
+	fromUSD := historicalRates(t, client, "USD", []string{"BRL", "EUR"}, date)
+	fromBRL := historicalRates(t, client, "BRL", []string{"USD"}, date)
+	fromEUR := historicalRates(t, client, "EUR", []string{"BRL"}, date)
+
+	// Assert
+	one := decimal.NewFromInt(1)
+	assert.True(t, fromUSD["BRL"].Mul(fromBRL["USD"]).Sub(one).Abs().LessThan(tolerance))
+	assert.True(t, fromEUR["BRL"].Sub(fromUSD["BRL"].Div(fromUSD["EUR"])).Abs().LessThan(tolerance))
+}
+
+func TestSimulatedClient_GetHistoricalRates_Drift(t *testing.T) {
+	// Arrange
+	walk := newRandomWalk(1)
+	walk.Drift = 0.1
+	walk.Volatility = 0
+	client := exchangerate.NewSimulatedClient(walk)
+
+	// Act
+	rates := historicalRates(t, client, "USD", []string{"BRL"}, walkStart.Add(365*24*time.Hour))
+
+	// Assert
+	assert.Equal(t, "5.5258545904", rates["BRL"].String()) // 5 * e^0.1
+}
+
+func TestSimulatedClient_GetExchangeRates_UnknownCurrencies(t *testing.T) {
+	// Arrange
+	client := exchangerate.NewSimulatedClient(newRandomWalk(1))
+
+	// Act
+	rates, err := client.GetExchangeRates(context.Background(), "USD", []string{"BRL", "XYZ"})
+	_, baseErr := client.GetExchangeRates(context.Background(), "XYZ", []string{"BRL"})
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrUnknownCurrency)
+	assert.ErrorContains(t, err, "USD-XYZ")
+	assert.Len(t, rates, 1)
+	assert.ErrorIs(t, baseErr, exchangerate.ErrUnknownCurrency)
+}
@@ -1,10 +1,20 @@
 package exchangerate
 
+import "github.com/shopspring/decimal"
+
 type GetExchangeRateRequest struct {
 	From string
 	To   string
 }
 
 type GetExchangeRateResponse struct {
-	Rate float64 `json:"result"`
+	Rate decimal.Decimal `json:"result"`
+
+	// Providers lists the providers whose quotes made up Rate. Only composite
+	// clients set it.
+	Providers []string `json:"-"`
+
+	// Spread is how far apart the providers' quotes were, as (max - min) / Rate.
+	// Only aggregating clients set it.
+	Spread decimal.NullDecimal `json:"-"`
 }
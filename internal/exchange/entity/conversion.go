@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type ConvertRequest struct {
+	From   string
+	To     string
+	Amount decimal.Decimal
+}
+
+// ConversionStep is one rate applied while converting. Inverted steps use the
+// stored rate of the opposite pair, so Rate is its reciprocal.
+type ConversionStep struct {
+	From            string          `json:"from" example:"EUR"`
+	To              string          `json:"to" example:"USD"`
+	Rate            decimal.Decimal `json:"rate" format:"decimal" example:"1.08"`
+	Inverted        bool            `json:"inverted" description:"Whether the rate was derived from the opposite pair"`
+	LastAcquisition time.Time       `json:"last_acquisition"`
+}
+
+type ConvertResponse struct {
+	From   string           `json:"from" example:"EUR"`
+	To     string           `json:"to" example:"BRL"`
+	Amount decimal.Decimal  `json:"amount" format:"decimal" example:"123.45"`
+	Result decimal.Decimal  `json:"result" format:"decimal" description:"Converted amount rounded to the target currency's ISO 4217 minor units" example:"699.63"`
+	Rate   decimal.Decimal  `json:"rate" format:"decimal" description:"Effective rate from the source to the target currency" example:"5.667"`
+	Path   []string         `json:"path" description:"Currencies the conversion went through" example:"[\"EUR\",\"USD\",\"BRL\"]"`
+	Rates  []ConversionStep `json:"rates" description:"Rates applied, in order"`
+}
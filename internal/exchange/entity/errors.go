@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrExchangeNotFound is returned when no exchange is stored for a pair.
+	ErrExchangeNotFound = errors.New("exchange not found")
+
+	// ErrConversionPathNotFound is returned when no stored rate links two currencies,
+	// directly, inversely or through a pivot currency.
+	ErrConversionPathNotFound = errors.New("conversion path not found")
+)
+
+// FieldError tells why a single request field is invalid.
+type FieldError struct {
+	Field   string `json:"field" example:"source"`
+	Message string `json:"message" example:"\"ABC\" is not an ISO 4217 currency code"`
+}
+
+// ValidationError is returned when a request has invalid fields, listing all of them.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Add records an invalid field.
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// OrNil returns the error when it has any invalid field and nil otherwise.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
@@ -1,22 +1,48 @@
 package entity
 
-import "time"
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
 
 type Exchange struct {
-	ID             uint64  `ksql:"id"`
-	BaseCurrency   string  `ksql:"base_currency"`
-	TargetCurrency string  `ksql:"target_currency"`
-	Rate           float64 `ksql:"rate"`
+	ID             uint64          `ksql:"id"`
+	BaseCurrency   string          `ksql:"base_currency"`
+	TargetCurrency string          `ksql:"target_currency"`
+	Rate           decimal.Decimal `ksql:"rate"`
 
 	CreatedAt time.Time  `ksql:"created_at"`
 	UpdatedAt *time.Time `ksql:"updated_at"`
 }
 
+// ExchangeRate is a single observation of an exchange rate, kept as history.
+type ExchangeRate struct {
+	ID         uint64          `ksql:"id"`
+	ExchangeID uint64          `ksql:"exchange_id"`
+	Rate       decimal.Decimal `ksql:"rate"`
+	CreatedAt  time.Time       `ksql:"created_at"`
+
+	// Spread is how far apart the providers' quotes were, relative to Rate.
+	// It is null when the rate wasn't aggregated across providers.
+	Spread decimal.NullDecimal `ksql:"spread"`
+}
+
+// ExchangeSnapshot is an exchange with the rate that was in effect at a past
+// moment, observed at ObservedAt.
+type ExchangeSnapshot struct {
+	ID             uint64          `ksql:"id"`
+	BaseCurrency   string          `ksql:"base_currency"`
+	TargetCurrency string          `ksql:"target_currency"`
+	Rate           decimal.Decimal `ksql:"rate"`
+	ObservedAt     time.Time       `ksql:"observed_at"`
+}
+
 type ExchangeResponse struct {
-	ID             uint64  `json:"id"`
-	SourceCurrency string  `json:"source_currency"`
-	TargetCurrency string  `json:"target_currency"`
-	Rate           float64 `json:"rate"`
+	ID             uint64          `json:"id"`
+	SourceCurrency string          `json:"source_currency"`
+	TargetCurrency string          `json:"target_currency"`
+	Rate           decimal.Decimal `json:"rate" format:"decimal" example:"5.123456789012345"`
 
-	LastAcquisition time.Time `json:"last_acquisition"`
+	LastAcquisition time.Time  `json:"last_acquisition" description:"When the rate was observed"`
+	AsOf            *time.Time `json:"as_of,omitempty" description:"Moment the rate was requested for, when looking up a past rate"`
 }
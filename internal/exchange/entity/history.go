@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// HistoryInterval is the width of the buckets exchange rate history is grouped into.
+type HistoryInterval string
+
+const (
+	HistoryIntervalHour HistoryInterval = "1h"
+	HistoryIntervalDay  HistoryInterval = "1d"
+	HistoryIntervalWeek HistoryInterval = "1w"
+)
+
+// ParseHistoryInterval validates an interval, defaulting to a day when empty.
+func ParseHistoryInterval(s string) (HistoryInterval, error) {
+	switch interval := HistoryInterval(s); interval {
+	case "":
+		return HistoryIntervalDay, nil
+	case HistoryIntervalHour, HistoryIntervalDay, HistoryIntervalWeek:
+		return interval, nil
+	default:
+		return "", fmt.Errorf("invalid interval %q, expected 1h, 1d or 1w", s)
+	}
+}
+
+// Truncate returns the start of the bucket t falls into. Buckets are aligned in
+// UTC and weeks start on Monday.
+func (i HistoryInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case HistoryIntervalHour:
+		return t.Truncate(time.Hour)
+	case HistoryIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// HistoryAggregation is how the rates inside a bucket are reduced to one value.
+type HistoryAggregation string
+
+const (
+	HistoryAggregationLast    HistoryAggregation = "last"
+	HistoryAggregationAverage HistoryAggregation = "avg"
+)
+
+// ParseHistoryAggregation validates an aggregation, defaulting to the last value when empty.
+func ParseHistoryAggregation(s string) (HistoryAggregation, error) {
+	switch aggregation := HistoryAggregation(s); aggregation {
+	case "":
+		return HistoryAggregationLast, nil
+	case HistoryAggregationLast, HistoryAggregationAverage:
+		return aggregation, nil
+	default:
+		return "", fmt.Errorf("invalid aggregation %q, expected last or avg", s)
+	}
+}
+
+type ExchangeHistoryRequest struct {
+	SourceCurrency string
+	TargetCurrency string
+	From           time.Time
+	To             time.Time
+	Interval       HistoryInterval
+	Aggregation    HistoryAggregation
+}
+
+type ExchangeHistoryPoint struct {
+	Time    time.Time       `json:"time" description:"Start of the bucket, in UTC"`
+	Rate    decimal.Decimal `json:"rate" format:"decimal" example:"5.123456789012345"`
+	Samples int             `json:"samples" description:"Number of rates observed in the bucket" example:"3"`
+}
+
+type ExchangeHistoryResponse struct {
+	SourceCurrency string                 `json:"source_currency" example:"USD"`
+	TargetCurrency string                 `json:"target_currency" example:"BRL"`
+	From           time.Time              `json:"from"`
+	To             time.Time              `json:"to"`
+	Interval       HistoryInterval        `json:"interval" enum:"1h,1d,1w" example:"1d"`
+	Aggregation    HistoryAggregation     `json:"aggregation" enum:"last,avg" example:"last"`
+	Points         []ExchangeHistoryPoint `json:"points"`
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/entity (interfaces: ProviderUsageService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_usage.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity ProviderUsageService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProviderUsageService is a mock of ProviderUsageService interface.
+type MockProviderUsageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderUsageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProviderUsageServiceMockRecorder is the mock recorder for MockProviderUsageService.
+type MockProviderUsageServiceMockRecorder struct {
+	mock *MockProviderUsageService
+}
+
+// NewMockProviderUsageService creates a new mock instance.
+func NewMockProviderUsageService(ctrl *gomock.Controller) *MockProviderUsageService {
+	mock := &MockProviderUsageService{ctrl: ctrl}
+	mock.recorder = &MockProviderUsageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderUsageService) EXPECT() *MockProviderUsageServiceMockRecorder {
+	return m.recorder
+}
+
+// CountProviderCalls mocks base method.
+func (m *MockProviderUsageService) CountProviderCalls(ctx context.Context, provider string, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProviderCalls", ctx, provider, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProviderCalls indicates an expected call of CountProviderCalls.
+func (mr *MockProviderUsageServiceMockRecorder) CountProviderCalls(ctx, provider, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProviderCalls", reflect.TypeOf((*MockProviderUsageService)(nil).CountProviderCalls), ctx, provider, at)
+}
+
+// RecordProviderCalls mocks base method.
+func (m *MockProviderUsageService) RecordProviderCalls(ctx context.Context, provider string, at time.Time, calls int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordProviderCalls", ctx, provider, at, calls)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordProviderCalls indicates an expected call of RecordProviderCalls.
+func (mr *MockProviderUsageServiceMockRecorder) RecordProviderCalls(ctx, provider, at, calls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordProviderCalls", reflect.TypeOf((*MockProviderUsageService)(nil).RecordProviderCalls), ctx, provider, at, calls)
+}
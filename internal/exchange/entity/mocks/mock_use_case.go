@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/entity (interfaces: SyncExchangeRateUseCase,ListExchangesUseCase,ExchangeService)
+// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/entity (interfaces: SyncExchangeRateUseCase,SyncExchangeRatesUseCase,BackfillExchangeRatesUseCase,ListExchangesUseCase,GetExchangeUseCase,ExchangeHistoryUseCase,ConvertUseCase,ExchangeService)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mock_use_case.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity SyncExchangeRateUseCase,ListExchangesUseCase,ExchangeService
+//	mockgen -destination=mocks/mock_use_case.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity SyncExchangeRateUseCase,SyncExchangeRatesUseCase,BackfillExchangeRatesUseCase,ListExchangesUseCase,GetExchangeUseCase,ExchangeHistoryUseCase,ConvertUseCase,ExchangeService
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,8 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	decimal "github.com/shopspring/decimal"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -56,6 +58,84 @@ func (mr *MockSyncExchangeRateUseCaseMockRecorder) Execute(ctx, req any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockSyncExchangeRateUseCase)(nil).Execute), ctx, req)
 }
 
+// MockSyncExchangeRatesUseCase is a mock of SyncExchangeRatesUseCase interface.
+type MockSyncExchangeRatesUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncExchangeRatesUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncExchangeRatesUseCaseMockRecorder is the mock recorder for MockSyncExchangeRatesUseCase.
+type MockSyncExchangeRatesUseCaseMockRecorder struct {
+	mock *MockSyncExchangeRatesUseCase
+}
+
+// NewMockSyncExchangeRatesUseCase creates a new mock instance.
+func NewMockSyncExchangeRatesUseCase(ctrl *gomock.Controller) *MockSyncExchangeRatesUseCase {
+	mock := &MockSyncExchangeRatesUseCase{ctrl: ctrl}
+	mock.recorder = &MockSyncExchangeRatesUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncExchangeRatesUseCase) EXPECT() *MockSyncExchangeRatesUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockSyncExchangeRatesUseCase) Execute(ctx context.Context, req entity.SyncExchangeRatesRequest) (*entity.SyncExchangeRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req)
+	ret0, _ := ret[0].(*entity.SyncExchangeRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockSyncExchangeRatesUseCaseMockRecorder) Execute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockSyncExchangeRatesUseCase)(nil).Execute), ctx, req)
+}
+
+// MockBackfillExchangeRatesUseCase is a mock of BackfillExchangeRatesUseCase interface.
+type MockBackfillExchangeRatesUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackfillExchangeRatesUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockBackfillExchangeRatesUseCaseMockRecorder is the mock recorder for MockBackfillExchangeRatesUseCase.
+type MockBackfillExchangeRatesUseCaseMockRecorder struct {
+	mock *MockBackfillExchangeRatesUseCase
+}
+
+// NewMockBackfillExchangeRatesUseCase creates a new mock instance.
+func NewMockBackfillExchangeRatesUseCase(ctrl *gomock.Controller) *MockBackfillExchangeRatesUseCase {
+	mock := &MockBackfillExchangeRatesUseCase{ctrl: ctrl}
+	mock.recorder = &MockBackfillExchangeRatesUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackfillExchangeRatesUseCase) EXPECT() *MockBackfillExchangeRatesUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockBackfillExchangeRatesUseCase) Execute(ctx context.Context, req entity.BackfillExchangeRatesRequest) (*entity.BackfillExchangeRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req)
+	ret0, _ := ret[0].(*entity.BackfillExchangeRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockBackfillExchangeRatesUseCaseMockRecorder) Execute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockBackfillExchangeRatesUseCase)(nil).Execute), ctx, req)
+}
+
 // MockListExchangesUseCase is a mock of ListExchangesUseCase interface.
 type MockListExchangesUseCase struct {
 	ctrl     *gomock.Controller
@@ -95,6 +175,123 @@ func (mr *MockListExchangesUseCaseMockRecorder) Execute(ctx, req any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockListExchangesUseCase)(nil).Execute), ctx, req)
 }
 
+// MockGetExchangeUseCase is a mock of GetExchangeUseCase interface.
+type MockGetExchangeUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetExchangeUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockGetExchangeUseCaseMockRecorder is the mock recorder for MockGetExchangeUseCase.
+type MockGetExchangeUseCaseMockRecorder struct {
+	mock *MockGetExchangeUseCase
+}
+
+// NewMockGetExchangeUseCase creates a new mock instance.
+func NewMockGetExchangeUseCase(ctrl *gomock.Controller) *MockGetExchangeUseCase {
+	mock := &MockGetExchangeUseCase{ctrl: ctrl}
+	mock.recorder = &MockGetExchangeUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetExchangeUseCase) EXPECT() *MockGetExchangeUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockGetExchangeUseCase) Execute(ctx context.Context, req entity.GetExchangeRequest) (*entity.ExchangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req)
+	ret0, _ := ret[0].(*entity.ExchangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockGetExchangeUseCaseMockRecorder) Execute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockGetExchangeUseCase)(nil).Execute), ctx, req)
+}
+
+// MockExchangeHistoryUseCase is a mock of ExchangeHistoryUseCase interface.
+type MockExchangeHistoryUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeHistoryUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockExchangeHistoryUseCaseMockRecorder is the mock recorder for MockExchangeHistoryUseCase.
+type MockExchangeHistoryUseCaseMockRecorder struct {
+	mock *MockExchangeHistoryUseCase
+}
+
+// NewMockExchangeHistoryUseCase creates a new mock instance.
+func NewMockExchangeHistoryUseCase(ctrl *gomock.Controller) *MockExchangeHistoryUseCase {
+	mock := &MockExchangeHistoryUseCase{ctrl: ctrl}
+	mock.recorder = &MockExchangeHistoryUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeHistoryUseCase) EXPECT() *MockExchangeHistoryUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockExchangeHistoryUseCase) Execute(ctx context.Context, req entity.ExchangeHistoryRequest) (*entity.ExchangeHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req)
+	ret0, _ := ret[0].(*entity.ExchangeHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockExchangeHistoryUseCaseMockRecorder) Execute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockExchangeHistoryUseCase)(nil).Execute), ctx, req)
+}
+
+// MockConvertUseCase is a mock of ConvertUseCase interface.
+type MockConvertUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConvertUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockConvertUseCaseMockRecorder is the mock recorder for MockConvertUseCase.
+type MockConvertUseCaseMockRecorder struct {
+	mock *MockConvertUseCase
+}
+
+// NewMockConvertUseCase creates a new mock instance.
+func NewMockConvertUseCase(ctrl *gomock.Controller) *MockConvertUseCase {
+	mock := &MockConvertUseCase{ctrl: ctrl}
+	mock.recorder = &MockConvertUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConvertUseCase) EXPECT() *MockConvertUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockConvertUseCase) Execute(ctx context.Context, req entity.ConvertRequest) (*entity.ConvertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req)
+	ret0, _ := ret[0].(*entity.ConvertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockConvertUseCaseMockRecorder) Execute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockConvertUseCase)(nil).Execute), ctx, req)
+}
+
 // MockExchangeService is a mock of ExchangeService interface.
 type MockExchangeService struct {
 	ctrl     *gomock.Controller
@@ -119,6 +316,36 @@ func (m *MockExchangeService) EXPECT() *MockExchangeServiceMockRecorder {
 	return m.recorder
 }
 
+// BackfillExchangeRate mocks base method.
+func (m *MockExchangeService) BackfillExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillExchangeRate", ctx, sourceCurrency, targetCurrency, rate, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillExchangeRate indicates an expected call of BackfillExchangeRate.
+func (mr *MockExchangeServiceMockRecorder) BackfillExchangeRate(ctx, sourceCurrency, targetCurrency, rate, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillExchangeRate", reflect.TypeOf((*MockExchangeService)(nil).BackfillExchangeRate), ctx, sourceCurrency, targetCurrency, rate, at)
+}
+
+// ListExchangeRates mocks base method.
+func (m *MockExchangeService) ListExchangeRates(ctx context.Context, sourceCurrency, targetCurrency string, from, to time.Time) ([]entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeRates", ctx, sourceCurrency, targetCurrency, from, to)
+	ret0, _ := ret[0].([]entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeRates indicates an expected call of ListExchangeRates.
+func (mr *MockExchangeServiceMockRecorder) ListExchangeRates(ctx, sourceCurrency, targetCurrency, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRates", reflect.TypeOf((*MockExchangeService)(nil).ListExchangeRates), ctx, sourceCurrency, targetCurrency, from, to)
+}
+
 // ListExchanges mocks base method.
 func (m *MockExchangeService) ListExchanges(ctx context.Context, sourceCurrency, targetCurrency string) ([]entity.Exchange, error) {
 	m.ctrl.T.Helper()
@@ -134,16 +361,31 @@ func (mr *MockExchangeServiceMockRecorder) ListExchanges(ctx, sourceCurrency, ta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchanges", reflect.TypeOf((*MockExchangeService)(nil).ListExchanges), ctx, sourceCurrency, targetCurrency)
 }
 
+// ListExchangesAsOf mocks base method.
+func (m *MockExchangeService) ListExchangesAsOf(ctx context.Context, sourceCurrency, targetCurrency string, asOf time.Time) ([]entity.ExchangeSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangesAsOf", ctx, sourceCurrency, targetCurrency, asOf)
+	ret0, _ := ret[0].([]entity.ExchangeSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangesAsOf indicates an expected call of ListExchangesAsOf.
+func (mr *MockExchangeServiceMockRecorder) ListExchangesAsOf(ctx, sourceCurrency, targetCurrency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangesAsOf", reflect.TypeOf((*MockExchangeService)(nil).ListExchangesAsOf), ctx, sourceCurrency, targetCurrency, asOf)
+}
+
 // ReceiveExchangeRate mocks base method.
-func (m *MockExchangeService) ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate float64) error {
+func (m *MockExchangeService) ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, spread decimal.NullDecimal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReceiveExchangeRate", ctx, sourceCurrency, targetCurrency, rate)
+	ret := m.ctrl.Call(m, "ReceiveExchangeRate", ctx, sourceCurrency, targetCurrency, rate, spread)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReceiveExchangeRate indicates an expected call of ReceiveExchangeRate.
-func (mr *MockExchangeServiceMockRecorder) ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate any) *gomock.Call {
+func (mr *MockExchangeServiceMockRecorder) ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate, spread any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveExchangeRate", reflect.TypeOf((*MockExchangeService)(nil).ReceiveExchangeRate), ctx, sourceCurrency, targetCurrency, rate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveExchangeRate", reflect.TypeOf((*MockExchangeService)(nil).ReceiveExchangeRate), ctx, sourceCurrency, targetCurrency, rate, spread)
 }
@@ -0,0 +1,28 @@
+package entity
+
+//go:generate mockgen -destination=mocks/mock_usage.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity ProviderUsageService
+
+import (
+	"context"
+	"time"
+)
+
+// UsageMonth is the month, as YYYY-MM in UTC, provider calls made at t count towards.
+func UsageMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// ProviderUsage is how many calls a provider got in a month.
+type ProviderUsage struct {
+	Provider string `ksql:"provider"`
+	Month    string `ksql:"month"`
+	Calls    int64  `ksql:"calls"`
+}
+
+type ProviderUsageService interface {
+	// RecordProviderCalls adds calls to the provider's count for the month of at.
+	RecordProviderCalls(ctx context.Context, provider string, at time.Time, calls int64) error
+
+	// CountProviderCalls returns the calls made to the provider in the month of at.
+	CountProviderCalls(ctx context.Context, provider string, at time.Time) (int64, error)
+}
@@ -1,8 +1,12 @@
 package entity
 
-//go:generate mockgen -destination=mocks/mock_use_case.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity SyncExchangeRateUseCase,ListExchangesUseCase,ExchangeService
+//go:generate mockgen -destination=mocks/mock_use_case.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/entity SyncExchangeRateUseCase,SyncExchangeRatesUseCase,BackfillExchangeRatesUseCase,ListExchangesUseCase,GetExchangeUseCase,ExchangeHistoryUseCase,ConvertUseCase,ExchangeService
 
-import "context"
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"time"
+)
 
 type SyncExchangeRateRequest struct {
 	SourceCurrency string
@@ -10,28 +14,107 @@ type SyncExchangeRateRequest struct {
 }
 
 type SyncExchangeRateResponse struct {
-	Rate float64
+	Rate decimal.Decimal
+
+	// Providers and Spread are set when the rate is aggregated across providers.
+	Providers []string
+	Spread    decimal.NullDecimal
+}
+
+// SyncExchangeRatesRequest syncs every target of a source currency at once.
+type SyncExchangeRatesRequest struct {
+	SourceCurrency   string
+	TargetCurrencies []string
+}
+
+type SyncExchangeRatesResponse struct {
+	// Rates are the synced rates by target currency.
+	Rates map[string]SyncExchangeRateResponse
+}
+
+// BackfillExchangeRatesRequest fills the daily history of every target of a
+// source currency between two UTC days, inclusive.
+type BackfillExchangeRatesRequest struct {
+	SourceCurrency   string
+	TargetCurrencies []string
+	From             time.Time
+	To               time.Time
+}
+
+type BackfillExchangeRatesResponse struct {
+	// Created is how many daily rates were recorded and Skipped how many already were.
+	Created int
+	Skipped int
 }
 
 type ListExchangesRequest struct {
 	SourceCurrency string `json:"source_currency"`
 	TargetCurrency string `json:"target_currency"`
+
+	// AsOf looks up the rates in effect at a past moment instead of the latest ones.
+	AsOf *time.Time `json:"as_of"`
 }
 
 type ListExchangesResponse []ExchangeResponse
 
+type GetExchangeRequest struct {
+	SourceCurrency string
+	TargetCurrency string
+
+	// AsOf looks up the rate in effect at a past moment instead of the latest one.
+	AsOf *time.Time
+}
+
 type SyncExchangeRateUseCase interface {
 	Execute(ctx context.Context, req SyncExchangeRateRequest) (*SyncExchangeRateResponse, error)
 }
 
+// SyncExchangeRatesUseCase syncs the targets it can and reports the others in its
+// error, so the response may be partial even when the error isn't nil.
+type SyncExchangeRatesUseCase interface {
+	Execute(ctx context.Context, req SyncExchangeRatesRequest) (*SyncExchangeRatesResponse, error)
+}
+
+// BackfillExchangeRatesUseCase only asks providers for the days a target has no
+// rate yet, so running it again over the same range records nothing. Like
+// SyncExchangeRatesUseCase, the response may be partial when the error isn't nil.
+type BackfillExchangeRatesUseCase interface {
+	Execute(ctx context.Context, req BackfillExchangeRatesRequest) (*BackfillExchangeRatesResponse, error)
+}
+
 type ListExchangesUseCase interface {
 	Execute(ctx context.Context, req ListExchangesRequest) (*ListExchangesResponse, error)
 }
 
+type GetExchangeUseCase interface {
+	Execute(ctx context.Context, req GetExchangeRequest) (*ExchangeResponse, error)
+}
+
+type ExchangeHistoryUseCase interface {
+	Execute(ctx context.Context, req ExchangeHistoryRequest) (*ExchangeHistoryResponse, error)
+}
+
+type ConvertUseCase interface {
+	Execute(ctx context.Context, req ConvertRequest) (*ConvertResponse, error)
+}
+
 type ExchangeService interface {
-	// ReceiveExchangeRate creates a new exchange rate in the database.
-	ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate float64) error
+	// ReceiveExchangeRate creates a new exchange rate in the database. Spread is
+	// kept with the rate's history and is null for rates that weren't aggregated.
+	ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, spread decimal.NullDecimal) error
+
+	// BackfillExchangeRate records a past rate at the given moment unless the pair
+	// already has a rate on that UTC day, and reports whether it did. The exchange
+	// only takes the rate when none of its history is more recent.
+	BackfillExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, at time.Time) (bool, error)
 
 	// ListExchanges returns a list of exchanges.
 	ListExchanges(ctx context.Context, sourceCurrency, targetCurrency string) ([]Exchange, error)
+
+	// ListExchangesAsOf returns exchanges with the latest rate recorded at or before asOf.
+	// Exchanges without a rate by then are left out.
+	ListExchangesAsOf(ctx context.Context, sourceCurrency, targetCurrency string, asOf time.Time) ([]ExchangeSnapshot, error)
+
+	// ListExchangeRates returns the rates recorded for a pair between from and to, inclusive, oldest first.
+	ListExchangeRates(ctx context.Context, sourceCurrency, targetCurrency string, from, to time.Time) ([]ExchangeRate, error)
 }
@@ -0,0 +1,358 @@
+// Package exchangetest provides a conformance suite for entity.ExchangeService
+// implementations, so every backend is held to the same expectations.
+package exchangetest
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Factory returns an empty ExchangeService for a single test. Use t.Cleanup to release its resources.
+type Factory func(t *testing.T) entity.ExchangeService
+
+// RunServiceContract runs the behaviour every ExchangeService implementation
+// must share as subtests of t, building a fresh backend for each of them.
+func RunServiceContract(t *testing.T, factory Factory) {
+	t.Run("ListsNothingWhenEmpty", func(t *testing.T) {
+		service := factory(t)
+
+		exchanges, err := service.ListExchanges(context.Background(), "", "")
+		require.NoError(t, err)
+		assert.Len(t, exchanges, 0)
+	})
+
+	t.Run("CreatesExchange", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.123456789012345"), decimal.NullDecimal{})
+		require.NoError(t, err)
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+		assert.NotZero(t, exchanges[0].ID)
+		assert.Equal(t, "USD", exchanges[0].BaseCurrency)
+		assert.Equal(t, "BRL", exchanges[0].TargetCurrency)
+		assert.Equal(t, "5.123456789012345", exchanges[0].Rate.String()) // No float rounding
+		assert.False(t, exchanges[0].CreatedAt.IsZero())
+		assert.Nil(t, exchanges[0].UpdatedAt)
+	})
+
+	t.Run("UpdatesExchange", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		created, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, created, 1)
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.50"), decimal.NullDecimal{}))
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+		assert.Equal(t, created[0].ID, exchanges[0].ID)
+		assert.Equal(t, "5.5", exchanges[0].Rate.String())
+		require.NotNil(t, exchanges[0].UpdatedAt)
+		assert.False(t, exchanges[0].UpdatedAt.Before(exchanges[0].CreatedAt))
+	})
+
+	t.Run("KeepsDirectionsApart", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "BRL", "USD", decimal.RequireFromString("0.19"), decimal.NullDecimal{}))
+
+		usdBRL, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, usdBRL, 1)
+		assert.Equal(t, "5.25", usdBRL[0].Rate.String())
+
+		brlUSD, err := service.ListExchanges(ctx, "BRL", "USD")
+		require.NoError(t, err)
+		require.Len(t, brlUSD, 1)
+		assert.Equal(t, "0.19", brlUSD[0].Rate.String())
+		assert.NotEqual(t, usdBRL[0].ID, brlUSD[0].ID)
+	})
+
+	t.Run("AppendsHistory", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.30"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.35"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{}))
+
+		usdBRL := historyLen(t, service, "USD", "BRL")
+		assert.Equal(t, 3, usdBRL)
+
+		eurBRL := historyLen(t, service, "EUR", "BRL")
+		assert.Equal(t, 1, eurBRL)
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+		assert.Equal(t, "5.35", exchanges[0].Rate.String()) // Latest rate wins
+	})
+
+	t.Run("ListsExchangeRatesInRange", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		before := time.Now().UTC().Add(-time.Second)
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.30"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{}))
+		after := time.Now().UTC().Add(time.Second)
+
+		exchangeRates, err := service.ListExchangeRates(ctx, "USD", "BRL", before, after)
+		require.NoError(t, err)
+		require.Len(t, exchangeRates, 2)
+		assert.Equal(t, "5.25", exchangeRates[0].Rate.String()) // Oldest first
+		assert.Equal(t, "5.3", exchangeRates[1].Rate.String())
+		assert.Equal(t, exchangeRates[0].ExchangeID, exchangeRates[1].ExchangeID)
+		assert.False(t, exchangeRates[1].CreatedAt.Before(exchangeRates[0].CreatedAt))
+
+		past, err := service.ListExchangeRates(ctx, "USD", "BRL", before.Add(-time.Hour), before)
+		require.NoError(t, err)
+		assert.Len(t, past, 0)
+
+		missing, err := service.ListExchangeRates(ctx, "USD", "JPY", before, after)
+		require.NoError(t, err)
+		assert.Len(t, missing, 0)
+	})
+
+	t.Run("KeepsSpreadWithHistory", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.30"), decimal.NewNullDecimal(decimal.RequireFromString("0.0015"))))
+
+		exchangeRates, err := service.ListExchangeRates(ctx, "USD", "BRL", time.Time{}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, exchangeRates, 2)
+		assert.False(t, exchangeRates[0].Spread.Valid)
+		require.True(t, exchangeRates[1].Spread.Valid)
+		assert.Equal(t, "0.0015", exchangeRates[1].Spread.Decimal.String())
+	})
+
+	t.Run("BackfillsOncePerDay", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		created, err := service.BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("4.90"), day)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		created, err = service.BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("4.95"), day.Add(12*time.Hour))
+		require.NoError(t, err)
+		assert.False(t, created) // Same UTC day
+
+		exchangeRates, err := service.ListExchangeRates(ctx, "USD", "BRL", day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Len(t, exchangeRates, 1)
+		assert.Equal(t, "4.9", exchangeRates[0].Rate.String())
+		assert.True(t, exchangeRates[0].CreatedAt.Equal(day))
+		assert.False(t, exchangeRates[0].Spread.Valid)
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+		assert.Equal(t, "4.9", exchanges[0].Rate.String())
+	})
+
+	t.Run("BackfillKeepsLatestRate", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		today, err := service.BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.20"), time.Now().UTC())
+		require.NoError(t, err)
+		assert.False(t, today)
+
+		past := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		created, err := service.BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("4.90"), past)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+		assert.Equal(t, "5.25", exchanges[0].Rate.String()) // Backfilled history is older
+
+		exchangeRates, err := service.ListExchangeRates(ctx, "USD", "BRL", time.Time{}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, exchangeRates, 2)
+		assert.Equal(t, "4.9", exchangeRates[0].Rate.String()) // Oldest first
+		assert.Equal(t, "5.25", exchangeRates[1].Rate.String())
+
+		snapshots, err := service.ListExchangesAsOf(ctx, "USD", "BRL", past.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, "4.9", snapshots[0].Rate.String())
+	})
+
+	t.Run("ListsExchangesAsOf", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		beforeAll := time.Now().UTC().Add(-time.Second)
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{}))
+		time.Sleep(10 * time.Millisecond)
+		between := time.Now().UTC()
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.50"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "JPY", decimal.RequireFromString("150"), decimal.NullDecimal{}))
+
+		past, err := service.ListExchangesAsOf(ctx, "USD", "BRL", between)
+		require.NoError(t, err)
+		require.Len(t, past, 1)
+		assert.Equal(t, "USD", past[0].BaseCurrency)
+		assert.Equal(t, "BRL", past[0].TargetCurrency)
+		assert.Equal(t, "5.25", past[0].Rate.String())
+		assert.False(t, past[0].ObservedAt.After(between))
+
+		latest, err := service.ListExchangesAsOf(ctx, "USD", "BRL", time.Now().UTC().Add(time.Second))
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, "5.5", latest[0].Rate.String())
+		assert.True(t, latest[0].ObservedAt.After(between))
+
+		all, err := service.ListExchangesAsOf(ctx, "", "", between)
+		require.NoError(t, err)
+		assert.Len(t, all, 2) // USD-JPY had no rate yet
+
+		toBRL, err := service.ListExchangesAsOf(ctx, "", "BRL", between)
+		require.NoError(t, err)
+		assert.Len(t, toBRL, 2)
+
+		none, err := service.ListExchangesAsOf(ctx, "", "", beforeAll)
+		require.NoError(t, err)
+		assert.Len(t, none, 0)
+	})
+
+	t.Run("FiltersExchanges", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), decimal.NullDecimal{}))
+		require.NoError(t, service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{}))
+
+		all, err := service.ListExchanges(ctx, "", "")
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		fromUSD, err := service.ListExchanges(ctx, "USD", "")
+		require.NoError(t, err)
+		assert.Len(t, fromUSD, 2)
+		for _, exchange := range fromUSD {
+			assert.Equal(t, "USD", exchange.BaseCurrency)
+		}
+
+		toBRL, err := service.ListExchanges(ctx, "", "BRL")
+		require.NoError(t, err)
+		assert.Len(t, toBRL, 2)
+		for _, exchange := range toBRL {
+			assert.Equal(t, "BRL", exchange.TargetCurrency)
+		}
+
+		pair, err := service.ListExchanges(ctx, "EUR", "BRL")
+		require.NoError(t, err)
+		require.Len(t, pair, 1)
+		assert.Equal(t, "5.75", pair[0].Rate.String())
+
+		missing, err := service.ListExchanges(ctx, "USD", "JPY")
+		require.NoError(t, err)
+		assert.Len(t, missing, 0)
+	})
+
+	t.Run("ConcurrentWritersOnNewPair", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+		const writers = 25
+
+		start := make(chan struct{})
+		errs := make(chan error, writers)
+		var wg sync.WaitGroup
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				errs <- service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.New(500+int64(i), -2), decimal.NullDecimal{})
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+		require.NoError(t, err)
+		require.Len(t, exchanges, 1)
+
+		history := historyLen(t, service, "USD", "BRL")
+		assert.Equal(t, writers, history)
+	})
+
+	t.Run("ConcurrentWritersOnManyPairs", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+		targets := []string{"BRL", "EUR", "GBP", "JPY"}
+		const writesPerPair = 5
+
+		errs := make(chan error, len(targets)*writesPerPair)
+		var wg sync.WaitGroup
+		for _, target := range targets {
+			for i := 0; i < writesPerPair; i++ {
+				wg.Add(1)
+				go func(target string, i int) {
+					defer wg.Done()
+					errs <- service.ReceiveExchangeRate(ctx, "USD", target, decimal.New(100+int64(i), -2), decimal.NullDecimal{})
+				}(target, i)
+			}
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		exchanges, err := service.ListExchanges(ctx, "USD", "")
+		require.NoError(t, err)
+		assert.Len(t, exchanges, len(targets))
+
+		for _, target := range targets {
+			history := historyLen(t, service, "USD", target)
+			assert.Equal(t, writesPerPair, history, "history of USD-%s", target)
+		}
+	})
+}
+
+// historyLen counts every rate recorded for a pair.
+func historyLen(t *testing.T, service entity.ExchangeService, sourceCurrency, targetCurrency string) int {
+	t.Helper()
+
+	exchangeRates, err := service.ListExchangeRates(context.Background(), sourceCurrency, targetCurrency, time.Time{}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	return len(exchangeRates)
+}
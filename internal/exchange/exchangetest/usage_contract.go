@@ -0,0 +1,87 @@
+package exchangetest
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// UsageFactory returns a new, empty ProviderUsageService for each subtest.
+type UsageFactory func(t *testing.T) entity.ProviderUsageService
+
+// RunUsageContract checks that a ProviderUsageService implementation counts
+// provider calls by month.
+func RunUsageContract(t *testing.T, factory UsageFactory) {
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("CountsNothingWhenEmpty", func(t *testing.T) {
+		service := factory(t)
+
+		calls, err := service.CountProviderCalls(context.Background(), "freecurrencyapi", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), calls)
+	})
+
+	t.Run("AddsCallsWithinAMonth", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.RecordProviderCalls(ctx, "freecurrencyapi", march, 1))
+		require.NoError(t, service.RecordProviderCalls(ctx, "freecurrencyapi", march.AddDate(0, 0, 10), 2))
+
+		calls, err := service.CountProviderCalls(ctx, "freecurrencyapi", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), calls)
+	})
+
+	t.Run("KeepsMonthsAndProvidersApart", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, service.RecordProviderCalls(ctx, "freecurrencyapi", march, 1))
+		require.NoError(t, service.RecordProviderCalls(ctx, "freecurrencyapi", march.AddDate(0, 1, 0), 5))
+		require.NoError(t, service.RecordProviderCalls(ctx, "http", march, 7))
+
+		calls, err := service.CountProviderCalls(ctx, "freecurrencyapi", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), calls)
+
+		calls, err = service.CountProviderCalls(ctx, "freecurrencyapi", march.AddDate(0, 1, 0))
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), calls)
+
+		calls, err = service.CountProviderCalls(ctx, "http", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), calls)
+	})
+
+	t.Run("ConcurrentRecorders", func(t *testing.T) {
+		service := factory(t)
+		ctx := context.Background()
+
+		const recorders = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, recorders)
+		for range recorders {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- service.RecordProviderCalls(ctx, "freecurrencyapi", march, 1)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		calls, err := service.CountProviderCalls(ctx, "freecurrencyapi", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(recorders), calls)
+	})
+}
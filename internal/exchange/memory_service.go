@@ -0,0 +1,208 @@
+package exchange
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type pair struct {
+	source string
+	target string
+}
+
+// memoryExchangeService keeps exchanges and their history in memory. It is
+// safe for concurrent use and loses everything when the process exits.
+type memoryExchangeService struct {
+	mu sync.RWMutex
+
+	exchanges []entity.Exchange
+	byPair    map[pair]int
+	history   map[uint64][]entity.ExchangeRate
+
+	nextRateID uint64
+}
+
+func (m *memoryExchangeService) ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, spread decimal.NullDecimal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+	key := pair{source: sourceCurrency, target: targetCurrency}
+	i, ok := m.byPair[key]
+	if ok {
+		m.exchanges[i].Rate = rate
+		m.exchanges[i].UpdatedAt = &now
+	} else {
+		i = len(m.exchanges)
+		m.byPair[key] = i
+		m.exchanges = append(m.exchanges, entity.Exchange{
+			ID:             uint64(i + 1),
+			BaseCurrency:   sourceCurrency,
+			TargetCurrency: targetCurrency,
+			Rate:           rate,
+			CreatedAt:      now,
+		})
+	}
+
+	exchangeID := m.exchanges[i].ID
+	m.nextRateID++
+	m.history[exchangeID] = append(m.history[exchangeID], entity.ExchangeRate{
+		ID:         m.nextRateID,
+		ExchangeID: exchangeID,
+		Rate:       rate,
+		CreatedAt:  now,
+		Spread:     spread,
+	})
+
+	log.Debug().Msgf("received exchange rate for exchange %s-%s: %s", sourceCurrency, targetCurrency, rate)
+	return nil
+}
+
+func (m *memoryExchangeService) BackfillExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	at = at.UTC()
+	now := time.Now().UTC()
+	key := pair{source: sourceCurrency, target: targetCurrency}
+	i, ok := m.byPair[key]
+	if !ok {
+		i = len(m.exchanges)
+		m.byPair[key] = i
+		m.exchanges = append(m.exchanges, entity.Exchange{
+			ID:             uint64(i + 1),
+			BaseCurrency:   sourceCurrency,
+			TargetCurrency: targetCurrency,
+			Rate:           rate,
+			CreatedAt:      now,
+		})
+	}
+
+	exchangeID := m.exchanges[i].ID
+	history := m.history[exchangeID]
+	y, month, d := at.Date()
+	for _, exchangeRate := range history {
+		if ry, rm, rd := exchangeRate.CreatedAt.UTC().Date(); ry == y && rm == month && rd == d {
+			return false, nil
+		}
+	}
+
+	// Keep history oldest first, as the listings rely on it.
+	position := sort.Search(len(history), func(j int) bool {
+		return history[j].CreatedAt.After(at)
+	})
+	if ok && position == len(history) {
+		m.exchanges[i].Rate = rate
+		m.exchanges[i].UpdatedAt = &now
+	}
+
+	m.nextRateID++
+	m.history[exchangeID] = slices.Insert(history, position, entity.ExchangeRate{
+		ID:         m.nextRateID,
+		ExchangeID: exchangeID,
+		Rate:       rate,
+		CreatedAt:  at,
+	})
+
+	log.Debug().Msgf("backfilled exchange rate for exchange %s-%s on %s: %s", sourceCurrency, targetCurrency, at.Format(time.DateOnly), rate)
+	return true, nil
+}
+
+func (m *memoryExchangeService) ListExchanges(ctx context.Context, sourceCurrency, targetCurrency string) ([]entity.Exchange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exchanges := make([]entity.Exchange, 0, len(m.exchanges))
+	for _, exchange := range m.exchanges {
+		if sourceCurrency != "" && exchange.BaseCurrency != sourceCurrency {
+			continue
+		}
+
+		if targetCurrency != "" && exchange.TargetCurrency != targetCurrency {
+			continue
+		}
+
+		exchanges = append(exchanges, copyExchange(exchange))
+	}
+
+	return exchanges, nil
+}
+
+func (m *memoryExchangeService) ListExchangesAsOf(ctx context.Context, sourceCurrency, targetCurrency string, asOf time.Time) ([]entity.ExchangeSnapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshots := []entity.ExchangeSnapshot{}
+	for _, exchange := range m.exchanges {
+		if sourceCurrency != "" && exchange.BaseCurrency != sourceCurrency {
+			continue
+		}
+
+		if targetCurrency != "" && exchange.TargetCurrency != targetCurrency {
+			continue
+		}
+
+		history := m.history[exchange.ID]
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].CreatedAt.After(asOf) {
+				continue
+			}
+
+			snapshots = append(snapshots, entity.ExchangeSnapshot{
+				ID:             exchange.ID,
+				BaseCurrency:   exchange.BaseCurrency,
+				TargetCurrency: exchange.TargetCurrency,
+				Rate:           history[i].Rate,
+				ObservedAt:     history[i].CreatedAt,
+			})
+			break
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (m *memoryExchangeService) ListExchangeRates(ctx context.Context, sourceCurrency, targetCurrency string, from, to time.Time) ([]entity.ExchangeRate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exchangeRates := []entity.ExchangeRate{}
+	i, ok := m.byPair[pair{source: sourceCurrency, target: targetCurrency}]
+	if !ok {
+		return exchangeRates, nil
+	}
+
+	// History is appended in order, so it is already oldest first.
+	for _, exchangeRate := range m.history[m.exchanges[i].ID] {
+		if exchangeRate.CreatedAt.Before(from) || exchangeRate.CreatedAt.After(to) {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	return exchangeRates, nil
+}
+
+// copyExchange detaches UpdatedAt so callers can't change the stored exchange.
+func copyExchange(exchange entity.Exchange) entity.Exchange {
+	if exchange.UpdatedAt != nil {
+		updatedAt := *exchange.UpdatedAt
+		exchange.UpdatedAt = &updatedAt
+	}
+
+	return exchange
+}
+
+func NewInMemoryExchangeService() entity.ExchangeService {
+	return &memoryExchangeService{
+		byPair:  map[pair]int{},
+		history: map[uint64][]entity.ExchangeRate{},
+	}
+}
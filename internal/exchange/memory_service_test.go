@@ -0,0 +1,45 @@
+package exchange
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/exchangetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestInMemoryExchangeService_Contract(t *testing.T) {
+	exchangetest.RunServiceContract(t, func(t *testing.T) entity.ExchangeService {
+		return NewInMemoryExchangeService()
+	})
+}
+
+func TestInMemoryProviderUsageService_Contract(t *testing.T) {
+	exchangetest.RunUsageContract(t, func(t *testing.T) entity.ProviderUsageService {
+		return NewInMemoryProviderUsageService()
+	})
+}
+
+func TestInMemoryExchangeService_ListExchanges_ReturnsCopies(t *testing.T) {
+	// Arrange
+	service := NewInMemoryExchangeService()
+	ctx := context.Background()
+
+	require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}))
+	require.NoError(t, service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.50"), decimal.NullDecimal{}))
+
+	// Act - Mutate what the first call returned
+	exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+	require.NoError(t, err)
+	exchanges[0].Rate = decimal.Zero
+	*exchanges[0].UpdatedAt = time.Time{}
+
+	// Assert
+	exchanges, err = service.ListExchanges(ctx, "USD", "BRL")
+	require.NoError(t, err)
+	assert.Equal(t, "5.5", exchanges[0].Rate.String())
+	assert.False(t, exchanges[0].UpdatedAt.IsZero())
+}
@@ -0,0 +1,41 @@
+package exchange
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"sync"
+	"time"
+)
+
+type usageKey struct {
+	provider string
+	month    string
+}
+
+// memoryProviderUsageService counts provider calls in memory. It is safe for
+// concurrent use and forgets every count when the process exits.
+type memoryProviderUsageService struct {
+	mu    sync.Mutex
+	calls map[usageKey]int64
+}
+
+func (m *memoryProviderUsageService) RecordProviderCalls(ctx context.Context, provider string, at time.Time, calls int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls[usageKey{provider: provider, month: entity.UsageMonth(at)}] += calls
+	return nil
+}
+
+func (m *memoryProviderUsageService) CountProviderCalls(ctx context.Context, provider string, at time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.calls[usageKey{provider: provider, month: entity.UsageMonth(at)}], nil
+}
+
+func NewInMemoryProviderUsageService() entity.ProviderUsageService {
+	return &memoryProviderUsageService{
+		calls: map[usageKey]int64{},
+	}
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+)
+
+// AddExchangeRatesSpread records how far apart the providers' quotes were for
+// each rate. It is NULL for rates that weren't aggregated across providers.
+func AddExchangeRatesSpread(ctx context.Context, db infra.DB) error {
+	columnType := "NUMERIC(30,15)"
+	if db.Dialect() == infra.DialectSQLite {
+		columnType = "TEXT"
+	}
+
+	_, err := db.Exec(ctx, `ALTER TABLE exchange_rates ADD COLUMN spread `+columnType)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to add exchange_rates spread")
+		return err
+	}
+
+	return nil
+}
+
+func DropExchangeRatesSpread(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `ALTER TABLE exchange_rates DROP COLUMN spread`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to drop exchange_rates spread")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+)
+
+// AlterRatesToNumeric stores rates as exact decimals instead of floating point numbers.
+// SQLite tables store rates as TEXT from the start, so there is nothing to alter.
+func AlterRatesToNumeric(ctx context.Context, db infra.DB) error {
+	if db.Dialect() == infra.DialectSQLite {
+		return nil
+	}
+
+	for _, table := range []string{"exchanges", "exchange_rates"} {
+		_, err := db.Exec(ctx, `ALTER TABLE `+table+` ALTER COLUMN rate TYPE NUMERIC(30,15)`)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to alter %s rate to numeric", table)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AlterRatesToFloat(ctx context.Context, db infra.DB) error {
+	if db.Dialect() == infra.DialectSQLite {
+		return nil
+	}
+
+	for _, table := range []string{"exchanges", "exchange_rates"} {
+		_, err := db.Exec(ctx, `ALTER TABLE `+table+` ALTER COLUMN rate TYPE FLOAT`)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to alter %s rate to float", table)
+			return err
+		}
+	}
+
+	return nil
+}
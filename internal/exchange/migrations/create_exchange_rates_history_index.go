@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateExchangeRatesHistoryIndex lets history lookups range over a single exchange by time.
+func CreateExchangeRatesHistoryIndex(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `CREATE INDEX IF NOT EXISTS exchange_rates_exchange_id_created_at_idx ON exchange_rates (exchange_id, created_at)`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create exchange_rates history index")
+		return err
+	}
+
+	return nil
+}
+
+func DropExchangeRatesHistoryIndex(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `DROP INDEX IF EXISTS exchange_rates_exchange_id_created_at_idx`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to drop exchange_rates history index")
+		return err
+	}
+
+	return nil
+}
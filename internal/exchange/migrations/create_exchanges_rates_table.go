@@ -7,7 +7,7 @@ import (
 )
 
 func CreateExchangeRatesTable(ctx context.Context, db infra.DB) error {
-	_, err := db.Exec(ctx, `
+	query := `
 		CREATE TABLE IF NOT EXISTS exchange_rates (
 			id SERIAL PRIMARY KEY,
 			exchange_id BIGINT NOT NULL,
@@ -15,7 +15,20 @@ func CreateExchangeRatesTable(ctx context.Context, db infra.DB) error {
 			created_at TIMESTAMP NOT NULL DEFAULT (current_timestamp AT TIME ZONE 'UTC'),
 			FOREIGN KEY (exchange_id) REFERENCES exchanges(id) ON DELETE CASCADE
 		);
- 	`)
+ 	`
+	if db.Dialect() == infra.DialectSQLite {
+		query = `
+			CREATE TABLE IF NOT EXISTS exchange_rates (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				exchange_id BIGINT NOT NULL,
+				rate TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (exchange_id) REFERENCES exchanges(id) ON DELETE CASCADE
+			);
+		`
+	}
+
+	_, err := db.Exec(ctx, query)
 
 	if err != nil {
 		log.Error().Err(err).Msg("failed to create exchange_rates table")
@@ -24,3 +37,13 @@ func CreateExchangeRatesTable(ctx context.Context, db infra.DB) error {
 
 	return nil
 }
+
+func DropExchangeRatesTable(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `DROP TABLE IF EXISTS exchange_rates`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to drop exchange_rates table")
+		return err
+	}
+
+	return nil
+}
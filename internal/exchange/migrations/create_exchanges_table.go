@@ -7,7 +7,7 @@ import (
 )
 
 func CreateExchangesTable(ctx context.Context, db infra.DB) error {
-	_, err := db.Exec(ctx, `
+	query := `
 		CREATE TABLE IF NOT EXISTS exchanges (
 			id SERIAL PRIMARY KEY,
 			base_currency VARCHAR(3) NOT NULL,
@@ -17,7 +17,23 @@ func CreateExchangesTable(ctx context.Context, db infra.DB) error {
 			updated_at TIMESTAMP NULL,
 			UNIQUE (base_currency, target_currency)
 		);
- 	`)
+ 	`
+	if db.Dialect() == infra.DialectSQLite {
+		// Rates are kept as TEXT, the NUMERIC affinity would turn them into lossy floats.
+		query = `
+			CREATE TABLE IF NOT EXISTS exchanges (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				base_currency VARCHAR(3) NOT NULL,
+				target_currency VARCHAR(3) NOT NULL,
+				rate TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NULL,
+				UNIQUE (base_currency, target_currency)
+			);
+		`
+	}
+
+	_, err := db.Exec(ctx, query)
 
 	if err != nil {
 		log.Error().Err(err).Msg("failed to create exchanges table")
@@ -26,3 +42,13 @@ func CreateExchangesTable(ctx context.Context, db infra.DB) error {
 
 	return nil
 }
+
+func DropExchangesTable(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `DROP TABLE IF EXISTS exchanges`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to drop exchanges table")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateProviderUsageTable counts the calls made to each exchange rate provider
+// by month, as YYYY-MM, so monthly quotas survive restarts.
+func CreateProviderUsageTable(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS provider_usage (
+			provider VARCHAR(64) NOT NULL,
+			month VARCHAR(7) NOT NULL,
+			calls BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (provider, month)
+		);
+	`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create provider_usage table")
+		return err
+	}
+
+	return nil
+}
+
+func DropProviderUsageTable(ctx context.Context, db infra.DB) error {
+	_, err := db.Exec(ctx, `DROP TABLE IF EXISTS provider_usage`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to drop provider_usage table")
+		return err
+	}
+
+	return nil
+}
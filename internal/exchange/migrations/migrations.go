@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+)
+
+// Migration is a versioned schema change. Up applies it and Down reverts it.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      func(ctx context.Context, db infra.DB) error
+	Down    func(ctx context.Context, db infra.DB) error
+}
+
+// All returns every known migration ordered by version. New migrations must
+// be appended with a version greater than the last one.
+func All() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create_exchanges_table",
+			Up:      CreateExchangesTable,
+			Down:    DropExchangesTable,
+		},
+		{
+			Version: 2,
+			Name:    "create_exchange_rates_table",
+			Up:      CreateExchangeRatesTable,
+			Down:    DropExchangeRatesTable,
+		},
+		{
+			Version: 3,
+			Name:    "alter_rates_to_numeric",
+			Up:      AlterRatesToNumeric,
+			Down:    AlterRatesToFloat,
+		},
+		{
+			Version: 4,
+			Name:    "create_exchange_rates_history_index",
+			Up:      CreateExchangeRatesHistoryIndex,
+			Down:    DropExchangeRatesHistoryIndex,
+		},
+		{
+			Version: 5,
+			Name:    "add_exchange_rates_spread",
+			Up:      AddExchangeRatesSpread,
+			Down:    DropExchangeRatesSpread,
+		},
+		{
+			Version: 6,
+			Name:    "create_provider_usage_table",
+			Up:      CreateProviderUsageTable,
+			Down:    DropProviderUsageTable,
+		},
+	}
+}
@@ -0,0 +1,327 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/rs/zerolog/log"
+	"sort"
+	"time"
+)
+
+// advisoryLockKey identifies the Postgres migration lock so that concurrent
+// `migrate` runs (e.g. several pods starting at once) apply each migration only once.
+const advisoryLockKey int64 = 4_735_201_993
+
+var (
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrNoDownStep     = errors.New("migration has no down step")
+)
+
+// MigrationStatus describes whether a migration was applied to the database.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator interface {
+	// Up applies every pending migration.
+	Up(ctx context.Context) error
+
+	// Down reverts the last `steps` applied migrations.
+	Down(ctx context.Context, steps int) error
+
+	// To migrates the database up or down until `version` is the last applied migration.
+	// Version 0 reverts every migration.
+	To(ctx context.Context, version uint64) error
+
+	// Status returns every known migration along with its applied state.
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+type schemaMigration struct {
+	Version   uint64    `ksql:"version"`
+	Name      string    `ksql:"name"`
+	AppliedAt time.Time `ksql:"applied_at"`
+}
+
+type migrator struct {
+	db         infra.DB
+	migrations []Migration
+}
+
+func (m migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+func (m migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(applied) - 1; i >= 0 && steps > 0; i-- {
+		err = m.revert(ctx, applied[i])
+		if err != nil {
+			return err
+		}
+		steps--
+	}
+
+	return nil
+}
+
+func (m migrator) To(ctx context.Context, version uint64) error {
+	if version != 0 && !m.isKnown(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	appliedVersions := make(map[uint64]bool, len(applied))
+	for _, migration := range applied {
+		appliedVersions[migration.Version] = true
+	}
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].Version <= version {
+			break
+		}
+
+		err = m.revert(ctx, applied[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+
+		if appliedVersions[migration.Version] {
+			continue
+		}
+
+		err = m.apply(ctx, migration)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	rows, err := m.schemaMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedAt := make(map[uint64]time.Time, len(rows))
+	for _, row := range rows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = &at
+		}
+	}
+
+	return statuses, nil
+}
+
+// apply runs the migration and records it in a single transaction. The
+// migration is skipped if another process applied it while we waited for the lock.
+func (m migrator) apply(ctx context.Context, migration Migration) error {
+	return m.db.Transaction(ctx, func(tx infra.DB) error {
+		applied, err := lockAndCheck(ctx, tx, migration.Version)
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			return nil
+		}
+
+		log.Info().Msgf("applying migration %d_%s", migration.Version, migration.Name)
+		err = migration.Up(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+		return err
+	})
+}
+
+// revert runs the down step of the migration and removes it from the
+// schema_migrations table in a single transaction.
+func (m migrator) revert(ctx context.Context, migration Migration) error {
+	if migration.Down == nil {
+		return fmt.Errorf("%w: %d_%s", ErrNoDownStep, migration.Version, migration.Name)
+	}
+
+	return m.db.Transaction(ctx, func(tx infra.DB) error {
+		applied, err := lockAndCheck(ctx, tx, migration.Version)
+		if err != nil {
+			return err
+		}
+
+		if !applied {
+			return nil
+		}
+
+		log.Info().Msgf("reverting migration %d_%s", migration.Version, migration.Name)
+		err = migration.Down(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+		return err
+	})
+}
+
+// appliedMigrations returns the known migrations recorded as applied, ordered by version.
+func (m migrator) appliedMigrations(ctx context.Context) ([]Migration, error) {
+	rows, err := m.schemaMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(rows))
+	for _, row := range rows {
+		migration, ok := m.find(row.Version)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d_%s is applied but not registered", ErrUnknownVersion, row.Version, row.Name)
+		}
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+func (m migrator) schemaMigrations(ctx context.Context) ([]schemaMigration, error) {
+	err := m.db.Transaction(ctx, func(tx infra.DB) error {
+		err := lock(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		return createSchemaMigrationsTable(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	err = m.db.Query(ctx, &rows, `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (m migrator) find(version uint64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+
+	return Migration{}, false
+}
+
+func (m migrator) isKnown(version uint64) bool {
+	_, ok := m.find(version)
+	return ok
+}
+
+// lock takes the migration lock for the running transaction. SQLite
+// transactions already hold the database write lock, so only Postgres needs
+// an advisory lock.
+func lock(ctx context.Context, tx infra.DB) error {
+	if tx.Dialect() != infra.DialectPostgres {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, advisoryLockKey)
+	return err
+}
+
+// lockAndCheck takes the migration lock for the running transaction and
+// reports whether the given version is already applied.
+func lockAndCheck(ctx context.Context, tx infra.DB, version uint64) (bool, error) {
+	err := lock(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	var row schemaMigration
+	err = tx.QueryOne(ctx, &row, `SELECT version, name, applied_at FROM schema_migrations WHERE version = $1`, version)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func createSchemaMigrationsTable(ctx context.Context, db infra.DB) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT (current_timestamp AT TIME ZONE 'UTC')
+		);
+ 	`
+	if db.Dialect() == infra.DialectSQLite {
+		query = `
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version BIGINT PRIMARY KEY,
+				name VARCHAR(255) NOT NULL,
+				applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`
+	}
+
+	_, err := db.Exec(ctx, query)
+
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create schema_migrations table")
+		return err
+	}
+
+	return nil
+}
+
+func NewMigrator(db infra.DB, migrations []Migration) Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return &migrator{
+		db:         db,
+		migrations: sorted,
+	}
+}
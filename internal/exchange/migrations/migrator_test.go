@@ -0,0 +1,266 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/jorgejr568/exchange-register-go/internal/infra/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// mockResult implements sql.Result for testing
+type mockResult struct{}
+
+func (m mockResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (m mockResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+// setupMigratorDB mocks the schema_migrations bookkeeping, reporting the given
+// versions as already applied.
+func setupMigratorDB(ctrl *gomock.Controller, applied ...uint64) *mocks.MockDB {
+	mockDB := mocks.NewMockDB(ctrl)
+
+	mockDB.EXPECT().
+		Dialect().
+		Return(infra.DialectPostgres).
+		AnyTimes()
+
+	mockDB.EXPECT().
+		Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx infra.DB) error) error {
+			return fn(mockDB)
+		}).
+		AnyTimes()
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `SELECT pg_advisory_xact_lock($1)`, advisoryLockKey).
+		Return(mockResult{}, nil).
+		AnyTimes()
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), gomock.Any()).
+		Return(mockResult{}, nil).
+		AnyTimes()
+
+	mockDB.EXPECT().
+		Query(gomock.Any(), gomock.Any(), `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`).
+		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
+			ptr := target.(*[]schemaMigration)
+			for _, version := range applied {
+				*ptr = append(*ptr, schemaMigration{Version: version, AppliedAt: time.Now()})
+			}
+			return nil
+		}).
+		AnyTimes()
+
+	mockDB.EXPECT().
+		QueryOne(gomock.Any(), gomock.Any(), `SELECT version, name, applied_at FROM schema_migrations WHERE version = $1`, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
+			for _, version := range applied {
+				if version == args[0].(uint64) {
+					return nil
+				}
+			}
+			return infra.ErrNotFound
+		}).
+		AnyTimes()
+
+	return mockDB
+}
+
+// recordingMigrations returns n migrations that append their version (or its
+// negation when reverted) to calls.
+func recordingMigrations(n int, calls *[]int) []Migration {
+	migrations := make([]Migration, n)
+	for i := range migrations {
+		version := i + 1
+		migrations[i] = Migration{
+			Version: uint64(version),
+			Name:    "test_migration",
+			Up: func(ctx context.Context, db infra.DB) error {
+				*calls = append(*calls, version)
+				return nil
+			},
+			Down: func(ctx context.Context, db infra.DB) error {
+				*calls = append(*calls, -version)
+				return nil
+			},
+		}
+	}
+
+	return migrations
+}
+
+func TestMigrator_Up_AppliesPendingMigrationsInOrder(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl, 1)
+	var calls []int
+	migrations := recordingMigrations(3, &calls)
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, uint64(2), "test_migration").
+		Return(mockResult{}, nil)
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, uint64(3), "test_migration").
+		Return(mockResult{}, nil)
+
+	// Registration order must not matter
+	migrator := NewMigrator(mockDB, []Migration{migrations[2], migrations[0], migrations[1]})
+
+	// Act
+	err := migrator.Up(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, calls)
+}
+
+func TestMigrator_Up_StopsOnFailure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl)
+	var calls []int
+	migrations := recordingMigrations(2, &calls)
+	expectedError := errors.New("syntax error")
+	migrations[0].Up = func(ctx context.Context, db infra.DB) error {
+		return expectedError
+	}
+
+	migrator := NewMigrator(mockDB, migrations)
+
+	// Act
+	err := migrator.Up(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.Empty(t, calls)
+}
+
+func TestMigrator_Down_RevertsLastAppliedMigrations(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl, 1, 2, 3)
+	var calls []int
+	migrator := NewMigrator(mockDB, recordingMigrations(3, &calls))
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `DELETE FROM schema_migrations WHERE version = $1`, uint64(3)).
+		Return(mockResult{}, nil)
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `DELETE FROM schema_migrations WHERE version = $1`, uint64(2)).
+		Return(mockResult{}, nil)
+
+	// Act
+	err := migrator.Down(context.Background(), 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{-3, -2}, calls)
+}
+
+func TestMigrator_To_MigratesDown(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl, 1, 2, 3)
+	var calls []int
+	migrator := NewMigrator(mockDB, recordingMigrations(3, &calls))
+
+	mockDB.EXPECT().
+		Exec(gomock.Any(), `DELETE FROM schema_migrations WHERE version = $1`, uint64(3)).
+		Return(mockResult{}, nil)
+
+	// Act
+	err := migrator.To(context.Background(), 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{-3}, calls)
+}
+
+func TestMigrator_To_UnknownVersion(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl)
+	var calls []int
+	migrator := NewMigrator(mockDB, recordingMigrations(2, &calls))
+
+	// Act
+	err := migrator.To(context.Background(), 42)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+	assert.Empty(t, calls)
+}
+
+func TestMigrator_Status(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := setupMigratorDB(ctrl, 1)
+	var calls []int
+	migrator := NewMigrator(mockDB, recordingMigrations(2, &calls))
+
+	// Act
+	statuses, err := migrator.Status(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestMigrator_SQLite_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db, err := infra.NewKsqlSQLiteDB(ctx, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	migrator := NewMigrator(db, All())
+
+	// Act & Assert - Every migration applies and reverts cleanly
+	require.NoError(t, migrator.Up(ctx))
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "migration %d should be applied", status.Version)
+	}
+
+	require.NoError(t, migrator.To(ctx, 1))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+
+	require.NoError(t, migrator.Up(ctx))
+	require.NoError(t, migrator.Down(ctx, len(All())))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.False(t, status.Applied, "migration %d should be reverted", status.Version)
+	}
+}
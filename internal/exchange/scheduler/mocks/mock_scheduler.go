@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler (interfaces: Scheduler,ProjectionReporter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_scheduler.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler Scheduler,ProjectionReporter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	scheduler "github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduler is a mock of Scheduler interface.
+type MockScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerMockRecorder is the mock recorder for MockScheduler.
+type MockSchedulerMockRecorder struct {
+	mock *MockScheduler
+}
+
+// NewMockScheduler creates a new mock instance.
+func NewMockScheduler(ctrl *gomock.Controller) *MockScheduler {
+	mock := &MockScheduler{ctrl: ctrl}
+	mock.recorder = &MockSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
+	return m.recorder
+}
+
+// Projection mocks base method.
+func (m *MockScheduler) Projection() scheduler.Projection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Projection")
+	ret0, _ := ret[0].(scheduler.Projection)
+	return ret0
+}
+
+// Projection indicates an expected call of Projection.
+func (mr *MockSchedulerMockRecorder) Projection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projection", reflect.TypeOf((*MockScheduler)(nil).Projection))
+}
+
+// Schedule mocks base method.
+func (m *MockScheduler) Schedule(ctx context.Context, now time.Time) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, now)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockSchedulerMockRecorder) Schedule(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockScheduler)(nil).Schedule), ctx, now)
+}
+
+// MockProjectionReporter is a mock of ProjectionReporter interface.
+type MockProjectionReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectionReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectionReporterMockRecorder is the mock recorder for MockProjectionReporter.
+type MockProjectionReporterMockRecorder struct {
+	mock *MockProjectionReporter
+}
+
+// NewMockProjectionReporter creates a new mock instance.
+func NewMockProjectionReporter(ctrl *gomock.Controller) *MockProjectionReporter {
+	mock := &MockProjectionReporter{ctrl: ctrl}
+	mock.recorder = &MockProjectionReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectionReporter) EXPECT() *MockProjectionReporterMockRecorder {
+	return m.recorder
+}
+
+// Projection mocks base method.
+func (m *MockProjectionReporter) Projection() scheduler.Projection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Projection")
+	ret0, _ := ret[0].(scheduler.Projection)
+	return ret0
+}
+
+// Projection indicates an expected call of Projection.
+func (mr *MockProjectionReporterMockRecorder) Projection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projection", reflect.TypeOf((*MockProjectionReporter)(nil).Projection))
+}
@@ -0,0 +1,170 @@
+package scheduler
+
+//go:generate mockgen -destination=mocks/mock_scheduler.go -package=mocks github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler Scheduler,ProjectionReporter
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"sync"
+	"time"
+)
+
+const (
+	// ModeFixed syncs every interval regardless of the budget.
+	ModeFixed = "fixed"
+	// ModeBudget spreads the calls left in the monthly budget over the rest of the month.
+	ModeBudget = "budget"
+)
+
+// Projection is where the monthly call budget stands and when the next sync runs.
+type Projection struct {
+	Mode         string    `json:"mode" example:"budget"`
+	Budget       int64     `json:"budget" description:"Monthly call budget, 0 when there is none"`
+	CallsUsed    int64     `json:"calls_used"`
+	CallsLeft    int64     `json:"calls_remaining" description:"Calls left this month, -1 when there is no budget"`
+	CallsPerSync int64     `json:"calls_per_sync"`
+	Interval     string    `json:"interval" example:"1h30m0s"`
+	NextRun      time.Time `json:"next_run"`
+}
+
+type ProjectionReporter interface {
+	// Projection returns the projection made by the last Schedule.
+	Projection() Projection
+}
+
+type Scheduler interface {
+	ProjectionReporter
+
+	// Schedule returns how long after now the next sync should run.
+	Schedule(ctx context.Context, now time.Time) (time.Duration, error)
+}
+
+type scheduler struct {
+	mode         string
+	usage        entity.ProviderUsageService
+	providers    []string
+	budget       int64
+	callsPerSync int64
+	interval     time.Duration
+
+	mu         sync.Mutex
+	projection Projection
+}
+
+func (s *scheduler) Schedule(ctx context.Context, now time.Time) (time.Duration, error) {
+	used, err := s.callsUsed(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	left := int64(-1)
+	if s.budget > 0 {
+		left = max(s.budget-used, 0)
+	}
+
+	interval := s.interval
+	if s.mode == ModeBudget {
+		interval = s.budgetInterval(left, now)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.projection = Projection{
+		Mode:         s.mode,
+		Budget:       s.budget,
+		CallsUsed:    used,
+		CallsLeft:    left,
+		CallsPerSync: s.callsPerSync,
+		Interval:     interval.Round(time.Second).String(),
+		NextRun:      now.Add(interval),
+	}
+
+	return interval, nil
+}
+
+// budgetInterval spreads the syncs the calls left pay for evenly over the rest
+// of the month, no closer than the minimum interval. When they pay for none,
+// it waits for the next month.
+func (s *scheduler) budgetInterval(left int64, now time.Time) time.Duration {
+	untilNextMonth := startOfNextMonth(now).Sub(now)
+	syncs := left / max(s.callsPerSync, 1)
+	if syncs == 0 {
+		return untilNextMonth
+	}
+
+	return max(untilNextMonth/time.Duration(syncs), s.interval)
+}
+
+// callsUsed sums the calls made to every provider this month.
+func (s *scheduler) callsUsed(ctx context.Context, now time.Time) (int64, error) {
+	var used int64
+	for _, provider := range s.providers {
+		calls, err := s.usage.CountProviderCalls(ctx, provider, now)
+		if err != nil {
+			return 0, err
+		}
+
+		used += calls
+	}
+
+	return used, nil
+}
+
+func (s *scheduler) Projection() Projection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projection
+}
+
+func startOfNextMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// CallsPerSync is how many provider calls a sync of requests takes: one per
+// source currency when the providers batch and one per pair otherwise, for each
+// of the providers queried.
+func CallsPerSync(requests []entity.SyncExchangeRatesRequest, batches bool, providersQueried int) int64 {
+	var calls int64
+	for _, request := range requests {
+		if batches {
+			calls++
+			continue
+		}
+
+		calls += int64(len(request.TargetCurrencies))
+	}
+
+	return calls * int64(providersQueried)
+}
+
+// NewFixedScheduler syncs every interval, projecting the budget, which may be
+// zero for none, from the calls made to providers.
+func NewFixedScheduler(usage entity.ProviderUsageService, providers []string, budget, callsPerSync int64, interval time.Duration) Scheduler {
+	return newScheduler(ModeFixed, usage, providers, budget, callsPerSync, interval)
+}
+
+// NewBudgetScheduler derives the interval from the calls left in the monthly
+// budget, recounting the calls made to providers on every Schedule so the
+// cadence adapts to them. Syncs are never closer than minInterval.
+func NewBudgetScheduler(usage entity.ProviderUsageService, providers []string, budget, callsPerSync int64, minInterval time.Duration) Scheduler {
+	return newScheduler(ModeBudget, usage, providers, budget, callsPerSync, minInterval)
+}
+
+func newScheduler(mode string, usage entity.ProviderUsageService, providers []string, budget, callsPerSync int64, interval time.Duration) *scheduler {
+	return &scheduler{
+		mode:         mode,
+		usage:        usage,
+		providers:    providers,
+		budget:       budget,
+		callsPerSync: callsPerSync,
+		interval:     interval,
+		projection: Projection{
+			Mode:         mode,
+			Budget:       budget,
+			CallsPerSync: callsPerSync,
+		},
+	}
+}
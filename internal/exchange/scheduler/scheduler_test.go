@@ -0,0 +1,122 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	entityMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+// now leaves 16 days until April.
+var now = time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)
+
+func usageWith(t *testing.T, calls map[string]int64) entity.ProviderUsageService {
+	usage := exchange.NewInMemoryProviderUsageService()
+	for provider, n := range calls {
+		require.NoError(t, usage.RecordProviderCalls(context.Background(), provider, now, n))
+	}
+
+	return usage
+}
+
+func TestBudgetScheduler_SpreadsCallsLeftOverTheMonth(t *testing.T) {
+	// Arrange
+	usage := usageWith(t, map[string]int64{"primary": 150, "secondary": 50})
+	s := scheduler.NewBudgetScheduler(usage, []string{"primary", "secondary"}, 1000, 4, time.Minute)
+
+	// Act
+	interval, err := s.Schedule(context.Background(), now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 16*24*time.Hour/200, interval) // 800 calls left pay for 200 syncs
+	assert.Equal(t, scheduler.Projection{
+		Mode:         scheduler.ModeBudget,
+		Budget:       1000,
+		CallsUsed:    200,
+		CallsLeft:    800,
+		CallsPerSync: 4,
+		Interval:     "1h55m12s",
+		NextRun:      now.Add(interval),
+	}, s.Projection())
+}
+
+func TestBudgetScheduler_KeepsMinInterval(t *testing.T) {
+	// Arrange
+	s := scheduler.NewBudgetScheduler(usageWith(t, nil), []string{"primary"}, 1_000_000, 1, time.Hour)
+
+	// Act
+	interval, err := s.Schedule(context.Background(), now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, interval)
+}
+
+func TestBudgetScheduler_WaitsForNextMonthWhenExhausted(t *testing.T) {
+	// Arrange
+	usage := usageWith(t, map[string]int64{"primary": 998})
+	s := scheduler.NewBudgetScheduler(usage, []string{"primary"}, 1000, 4, time.Minute)
+
+	// Act
+	interval, err := s.Schedule(context.Background(), now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 16*24*time.Hour, interval)
+	assert.Equal(t, int64(2), s.Projection().CallsLeft)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), s.Projection().NextRun)
+}
+
+func TestFixedScheduler_ProjectsWithoutBudget(t *testing.T) {
+	// Arrange
+	usage := usageWith(t, map[string]int64{"primary": 10})
+	s := scheduler.NewFixedScheduler(usage, []string{"primary"}, 0, 4, 30*time.Minute)
+
+	// Act
+	interval, err := s.Schedule(context.Background(), now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, interval)
+	assert.Equal(t, int64(10), s.Projection().CallsUsed)
+	assert.Equal(t, int64(-1), s.Projection().CallsLeft)
+	assert.Equal(t, "30m0s", s.Projection().Interval)
+}
+
+func TestScheduler_UsageError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usage := entityMocks.NewMockProviderUsageService(ctrl)
+	s := scheduler.NewBudgetScheduler(usage, []string{"primary"}, 1000, 4, time.Minute)
+	expectedError := errors.New("database connection failed")
+
+	usage.EXPECT().CountProviderCalls(gomock.Any(), "primary", now).Return(int64(0), expectedError)
+
+	// Act
+	_, err := s.Schedule(context.Background(), now)
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	assert.True(t, s.Projection().NextRun.IsZero())
+}
+
+func TestCallsPerSync(t *testing.T) {
+	requests := []entity.SyncExchangeRatesRequest{
+		{SourceCurrency: "USD", TargetCurrencies: []string{"BRL", "EUR"}},
+		{SourceCurrency: "EUR", TargetCurrencies: []string{"BRL"}},
+	}
+
+	assert.Equal(t, int64(2), scheduler.CallsPerSync(requests, true, 1))
+	assert.Equal(t, int64(3), scheduler.CallsPerSync(requests, false, 1))
+	assert.Equal(t, int64(6), scheduler.CallsPerSync(requests, false, 2))
+}
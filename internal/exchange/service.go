@@ -6,50 +6,88 @@ import (
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
 type ksqlExchangeService struct {
 	db infra.DB
 }
 
-func (k ksqlExchangeService) ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate float64) error {
-	exchange, err := k.getExchangeBySourceAndTarget(ctx, sourceCurrency, targetCurrency)
-	if err != nil {
-		if errors.Is(err, infra.ErrNotFound) {
-			createdID, err := k.createExchange(ctx, sourceCurrency, targetCurrency, rate)
-			if err != nil {
-				log.Error().Err(err).Msg("failed to create exchange")
-				return err
-			}
-			log.Debug().Msgf("created exchange with %s-%s with id %d", sourceCurrency, targetCurrency, createdID)
-			err = k.createExchangeRate(ctx, createdID, rate)
-			if err != nil {
-				log.Error().Err(err).Msg("failed to create exchange rate")
-				return err
-			}
-
-			log.Debug().Msgf("created exchange rate for exchange %s-%s: %f", sourceCurrency, targetCurrency, rate)
-			return nil
-		}
+func (k ksqlExchangeService) ReceiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, spread decimal.NullDecimal) error {
+	// The latest rate in exchanges and its history in exchange_rates must never disagree.
+	return k.db.Transaction(ctx, func(tx infra.DB) error {
+		return ksqlExchangeService{db: tx}.receiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate, spread)
+	})
+}
 
+func (k ksqlExchangeService) receiveExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, spread decimal.NullDecimal) error {
+	now := time.Now().UTC()
+	exchangeID, err := k.upsertExchange(ctx, sourceCurrency, targetCurrency, rate, now)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to upsert exchange %s-%s", sourceCurrency, targetCurrency)
 		return err
 	}
 
-	err = k.updateExchange(ctx, exchange.ID, rate)
+	log.Debug().Msgf("upserted exchange %s-%s with id %d: %s", sourceCurrency, targetCurrency, exchangeID, rate)
+	err = k.createExchangeRate(ctx, exchangeID, rate, spread, now)
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to update exchange %s-%s", sourceCurrency, targetCurrency)
+		log.Error().Err(err).Msgf("failed to create exchange rate for exchange %s-%s", sourceCurrency, targetCurrency)
 		return err
 	}
 
-	log.Debug().Msgf("updated exchange with id %s-%s: %f", sourceCurrency, targetCurrency, rate)
-	err = k.createExchangeRate(ctx, exchange.ID, rate)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to create exchange rate for exchange with id %s-%s", sourceCurrency, targetCurrency)
+	log.Debug().Msgf("created exchange rate for exchange %s-%s: %s", sourceCurrency, targetCurrency, rate)
+	return nil
+}
+
+func (k ksqlExchangeService) BackfillExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, at time.Time) (bool, error) {
+	var created bool
+	err := k.db.Transaction(ctx, func(tx infra.DB) error {
+		var err error
+		created, err = ksqlExchangeService{db: tx}.backfillExchangeRate(ctx, sourceCurrency, targetCurrency, rate, at.UTC())
 		return err
+	})
+	if err != nil {
+		return false, err
 	}
 
-	log.Debug().Msgf("created exchange rate for exchange %s-%s: %f", sourceCurrency, targetCurrency, rate)
-	return nil
+	return created, nil
+}
+
+func (k ksqlExchangeService) backfillExchangeRate(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, at time.Time) (bool, error) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	var existing entity.ExchangeRate
+	err := k.db.QueryOne(ctx, &existing, `SELECT r.id, r.exchange_id, r.rate, r.spread, r.created_at FROM exchange_rates r JOIN exchanges e ON e.id = r.exchange_id WHERE e.base_currency = $1 AND e.target_currency = $2 AND r.created_at >= $3 AND r.created_at < $4 LIMIT 1`, sourceCurrency, targetCurrency, day, day.AddDate(0, 0, 1))
+	if err == nil {
+		log.Debug().Msgf("exchange %s-%s already has a rate on %s", sourceCurrency, targetCurrency, day.Format(time.DateOnly))
+		return false, nil
+	}
+	if !errors.Is(err, infra.ErrNotFound) {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	var exchange entity.Exchange
+	err = k.db.QueryOne(ctx, &exchange, `SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2`, sourceCurrency, targetCurrency)
+	switch {
+	case errors.Is(err, infra.ErrNotFound):
+		exchange.ID, err = k.upsertExchange(ctx, sourceCurrency, targetCurrency, rate, now)
+	case err == nil:
+		_, err = k.db.Exec(ctx, `UPDATE exchanges SET rate = $1, updated_at = $2 WHERE id = $3 AND NOT EXISTS (SELECT 1 FROM exchange_rates WHERE exchange_id = $3 AND created_at > $4)`, rate, now, exchange.ID, at)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to backfill exchange %s-%s", sourceCurrency, targetCurrency)
+		return false, err
+	}
+
+	err = k.createExchangeRate(ctx, exchange.ID, rate, decimal.NullDecimal{}, at)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to backfill exchange rate for exchange %s-%s", sourceCurrency, targetCurrency)
+		return false, err
+	}
+
+	log.Debug().Msgf("backfilled exchange rate for exchange %s-%s on %s: %s", sourceCurrency, targetCurrency, day.Format(time.DateOnly), rate)
+	return true, nil
 }
 
 func (k ksqlExchangeService) ListExchanges(ctx context.Context, sourceCurrency, targetCurrency string) ([]entity.Exchange, error) {
@@ -89,42 +127,46 @@ func (k ksqlExchangeService) ListExchanges(ctx context.Context, sourceCurrency,
 	return exchangeRate, nil
 }
 
-func (k ksqlExchangeService) createExchangeRate(ctx context.Context, id uint64, rate float64) error {
-	_, err := k.db.Exec(ctx, `INSERT INTO exchange_rates (exchange_id, rate) VALUES ($1, $2)`, id, rate)
+func (k ksqlExchangeService) ListExchangesAsOf(ctx context.Context, sourceCurrency, targetCurrency string, asOf time.Time) ([]entity.ExchangeSnapshot, error) {
+	var snapshots []entity.ExchangeSnapshot
+	err := k.db.Query(ctx, &snapshots, `SELECT e.id, e.base_currency, e.target_currency, r.rate, r.created_at AS observed_at FROM exchanges e JOIN exchange_rates r ON r.exchange_id = e.id WHERE r.id = (SELECT l.id FROM exchange_rates l WHERE l.exchange_id = e.id AND l.created_at <= $1 ORDER BY l.created_at DESC, l.id DESC LIMIT 1) AND ($2 = '' OR e.base_currency = $2) AND ($3 = '' OR e.target_currency = $3) ORDER BY e.id`, asOf.UTC(), sourceCurrency, targetCurrency)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return snapshots, nil
 }
 
-func (k ksqlExchangeService) updateExchange(ctx context.Context, id uint64, rate float64) error {
-	_, err := k.db.Exec(ctx, `UPDATE exchanges SET rate = $1, updated_at = (now() at TIME ZONE 'UTC') WHERE id = $2`, rate, id)
+func (k ksqlExchangeService) ListExchangeRates(ctx context.Context, sourceCurrency, targetCurrency string, from, to time.Time) ([]entity.ExchangeRate, error) {
+	var exchangeRates []entity.ExchangeRate
+	err := k.db.Query(ctx, &exchangeRates, `SELECT r.id, r.exchange_id, r.rate, r.spread, r.created_at FROM exchange_rates r JOIN exchanges e ON e.id = r.exchange_id WHERE e.base_currency = $1 AND e.target_currency = $2 AND r.created_at >= $3 AND r.created_at <= $4 ORDER BY r.created_at, r.id`, sourceCurrency, targetCurrency, from.UTC(), to.UTC())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return exchangeRates, nil
 }
 
-func (k ksqlExchangeService) createExchange(ctx context.Context, sourceCurrency, targetCurrency string, rate float64) (uint64, error) {
-	var returningResult infra.ReturningID[uint64]
-	err := k.db.QueryOne(ctx, &returningResult, `INSERT INTO exchanges (base_currency, target_currency, rate) VALUES ($1, $2, $3) RETURNING id`, sourceCurrency, targetCurrency, rate)
+func (k ksqlExchangeService) createExchangeRate(ctx context.Context, id uint64, rate decimal.Decimal, spread decimal.NullDecimal, createdAt time.Time) error {
+	_, err := k.db.Exec(ctx, `INSERT INTO exchange_rates (exchange_id, rate, spread, created_at) VALUES ($1, $2, $3, $4)`, id, rate, spread, createdAt)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	return returningResult.ID, nil
+	return nil
 }
 
-func (k ksqlExchangeService) getExchangeBySourceAndTarget(ctx context.Context, sourceCurrency, targetCurrency string) (entity.Exchange, error) {
-	var exchange entity.Exchange
-	err := k.db.QueryOne(ctx, &exchange, `SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2 LIMIT 1`, sourceCurrency, targetCurrency)
+// upsertExchange creates the exchange or updates its rate in a single statement, so
+// concurrent writers receiving the same new pair can't collide on the unique constraint.
+// Timestamps are set by the application so both Postgres and SQLite store them alike.
+func (k ksqlExchangeService) upsertExchange(ctx context.Context, sourceCurrency, targetCurrency string, rate decimal.Decimal, now time.Time) (uint64, error) {
+	var returningResult infra.ReturningID[uint64]
+	err := k.db.QueryOne(ctx, &returningResult, `INSERT INTO exchanges (base_currency, target_currency, rate, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (base_currency, target_currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.created_at RETURNING id`, sourceCurrency, targetCurrency, rate, now)
 	if err != nil {
-		return entity.Exchange{}, err
+		return 0, err
 	}
 
-	return exchange, nil
+	return returningResult.ID, nil
 }
 
 func NewKSQLExchangeService(db infra.DB) entity.ExchangeService {
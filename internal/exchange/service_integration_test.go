@@ -5,10 +5,13 @@ package exchange
 import (
 	"context"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/exchangetest"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -52,7 +55,7 @@ func TestIntegration_ReceiveExchangeRate_CreateNew(t *testing.T) {
 	ctx := context.Background()
 
 	// Act
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 
 	// Assert
 	require.NoError(t, err)
@@ -63,7 +66,7 @@ func TestIntegration_ReceiveExchangeRate_CreateNew(t *testing.T) {
 	require.Len(t, exchanges, 1)
 	assert.Equal(t, "USD", exchanges[0].BaseCurrency)
 	assert.Equal(t, "BRL", exchanges[0].TargetCurrency)
-	assert.Equal(t, 5.25, exchanges[0].Rate)
+	assert.Equal(t, "5.25", exchanges[0].Rate.String())
 }
 
 func TestIntegration_ReceiveExchangeRate_UpdateExisting(t *testing.T) {
@@ -75,11 +78,11 @@ func TestIntegration_ReceiveExchangeRate_UpdateExisting(t *testing.T) {
 	ctx := context.Background()
 
 	// Create initial exchange
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Act - Update with new rate
-	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.50)
+	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.50"), decimal.NullDecimal{})
 
 	// Assert
 	require.NoError(t, err)
@@ -90,7 +93,7 @@ func TestIntegration_ReceiveExchangeRate_UpdateExisting(t *testing.T) {
 	require.Len(t, exchanges, 1)
 	assert.Equal(t, "USD", exchanges[0].BaseCurrency)
 	assert.Equal(t, "BRL", exchanges[0].TargetCurrency)
-	assert.Equal(t, 5.50, exchanges[0].Rate) // Updated rate
+	assert.Equal(t, "5.5", exchanges[0].Rate.String()) // Updated rate
 	assert.NotNil(t, exchanges[0].UpdatedAt)
 }
 
@@ -103,13 +106,13 @@ func TestIntegration_ListExchanges_NoFilters(t *testing.T) {
 	ctx := context.Background()
 
 	// Create multiple exchanges
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75)
+	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "GBP", "USD", 1.27)
+	err = service.ReceiveExchangeRate(ctx, "GBP", "USD", decimal.RequireFromString("1.27"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Act
@@ -129,13 +132,13 @@ func TestIntegration_ListExchanges_WithSourceFilter(t *testing.T) {
 	ctx := context.Background()
 
 	// Create multiple exchanges
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "USD", "EUR", 0.92)
+	err = service.ReceiveExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75)
+	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Act
@@ -158,13 +161,13 @@ func TestIntegration_ListExchanges_WithTargetFilter(t *testing.T) {
 	ctx := context.Background()
 
 	// Create multiple exchanges
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75)
+	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "GBP", "USD", 1.27)
+	err = service.ReceiveExchangeRate(ctx, "GBP", "USD", decimal.RequireFromString("1.27"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Act
@@ -187,13 +190,13 @@ func TestIntegration_ListExchanges_WithBothFilters(t *testing.T) {
 	ctx := context.Background()
 
 	// Create multiple exchanges
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "USD", "EUR", 0.92)
+	err = service.ReceiveExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75)
+	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Act
@@ -204,7 +207,7 @@ func TestIntegration_ListExchanges_WithBothFilters(t *testing.T) {
 	require.Len(t, exchanges, 1)
 	assert.Equal(t, "USD", exchanges[0].BaseCurrency)
 	assert.Equal(t, "BRL", exchanges[0].TargetCurrency)
-	assert.Equal(t, 5.25, exchanges[0].Rate)
+	assert.Equal(t, "5.25", exchanges[0].Rate.String())
 }
 
 func TestIntegration_ReceiveExchangeRate_CreatesHistoricalRates(t *testing.T) {
@@ -216,13 +219,13 @@ func TestIntegration_ReceiveExchangeRate_CreatesHistoricalRates(t *testing.T) {
 	ctx := context.Background()
 
 	// Act - Create and update exchange multiple times
-	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err := service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.30)
+	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.30"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.35)
+	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.35"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// Assert - Verify historical rates were created
@@ -235,7 +238,7 @@ func TestIntegration_ReceiveExchangeRate_CreatesHistoricalRates(t *testing.T) {
 	exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
 	require.NoError(t, err)
 	require.Len(t, exchanges, 1)
-	assert.Equal(t, 5.35, exchanges[0].Rate)
+	assert.Equal(t, "5.35", exchanges[0].Rate.String())
 }
 
 func TestIntegration_FullWorkflow(t *testing.T) {
@@ -254,10 +257,10 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	assert.Len(t, exchanges, 0)
 
 	// 2. Sync some exchanges
-	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.25)
+	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
-	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75)
+	err = service.ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// 3. List all exchanges
@@ -266,17 +269,71 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	assert.Len(t, exchanges, 2)
 
 	// 4. Update an existing exchange
-	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", 5.50)
+	err = service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.50"), decimal.NullDecimal{})
 	require.NoError(t, err)
 
 	// 5. Verify update
 	exchanges, err = service.ListExchanges(ctx, "USD", "BRL")
 	require.NoError(t, err)
 	require.Len(t, exchanges, 1)
-	assert.Equal(t, 5.50, exchanges[0].Rate)
+	assert.Equal(t, "5.5", exchanges[0].Rate.String())
 
 	// 6. Filter by target currency
 	exchanges, err = service.ListExchanges(ctx, "", "BRL")
 	require.NoError(t, err)
 	assert.Len(t, exchanges, 2)
 }
+
+func TestIntegration_ReceiveExchangeRate_ConcurrentWritersOnNewPair(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	defer cleanupTestDB(t, db)
+
+	service := NewKSQLExchangeService(db)
+	ctx := context.Background()
+	const writers = 25
+
+	// Act - Every writer receives the same, not yet existing, pair at once
+	start := make(chan struct{})
+	errs := make(chan error, writers)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs <- service.ReceiveExchangeRate(ctx, "USD", "BRL", decimal.New(500+int64(i), -2), decimal.NullDecimal{})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	// Assert - No writer collides on the unique constraint
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	exchanges, err := service.ListExchanges(ctx, "USD", "BRL")
+	require.NoError(t, err)
+	require.Len(t, exchanges, 1)
+
+	var result struct {
+		Count int `ksql:"count"`
+	}
+	err = db.QueryOne(ctx, &result, "SELECT COUNT(*) AS count FROM exchange_rates WHERE exchange_id = $1", exchanges[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, writers, result.Count) // Every write is kept in the history
+}
+
+func TestIntegration_ServiceContract(t *testing.T) {
+	exchangetest.RunServiceContract(t, func(t *testing.T) entity.ExchangeService {
+		db := setupTestDB(t)
+		// Contract tests expect an empty database
+		_, err := db.Exec(context.Background(), "DELETE FROM exchanges")
+		require.NoError(t, err)
+		t.Cleanup(func() { cleanupTestDB(t, db) })
+
+		return NewKSQLExchangeService(db)
+	})
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/exchangetest"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/migrations"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+// setupSQLiteDB creates a migrated SQLite database that lives for the duration of the test
+func setupSQLiteDB(t *testing.T) infra.DB {
+	ctx := context.Background()
+	db, err := infra.NewDB(ctx, "sqlite://"+filepath.Join(t.TempDir(), "exchange.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	err = migrations.NewMigrator(db, migrations.All()).Up(ctx)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestSQLiteExchangeService_Contract(t *testing.T) {
+	exchangetest.RunServiceContract(t, func(t *testing.T) entity.ExchangeService {
+		return NewKSQLExchangeService(setupSQLiteDB(t))
+	})
+}
+
+func TestSQLiteProviderUsageService_Contract(t *testing.T) {
+	exchangetest.RunUsageContract(t, func(t *testing.T) entity.ProviderUsageService {
+		return NewKSQLProviderUsageService(setupSQLiteDB(t))
+	})
+}
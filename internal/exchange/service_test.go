@@ -6,6 +6,7 @@ import (
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
 	"github.com/jorgejr568/exchange-register-go/internal/infra"
 	"github.com/jorgejr568/exchange-register-go/internal/infra/mocks"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -27,6 +28,15 @@ func (m mockResult) RowsAffected() (int64, error) {
 	return m.rowsAffected, nil
 }
 
+// expectTransaction makes the mock run transactional callbacks against itself.
+func expectTransaction(ctx context.Context, mockDB *mocks.MockDB) {
+	mockDB.EXPECT().
+		Transaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx infra.DB) error) error {
+			return fn(mockDB)
+		})
+}
+
 func TestKsqlExchangeService_ListExchanges_NoFilters(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -43,8 +53,8 @@ func TestKsqlExchangeService_ListExchanges_NoFilters(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 			ptr := target.(*[]entity.Exchange)
 			*ptr = []entity.Exchange{
-				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: 5.25},
-				{ID: 2, BaseCurrency: "EUR", TargetCurrency: "BRL", Rate: 5.75},
+				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.25")},
+				{ID: 2, BaseCurrency: "EUR", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.75")},
 			}
 			return nil
 		})
@@ -75,7 +85,7 @@ func TestKsqlExchangeService_ListExchanges_WithSourceFilter(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 			ptr := target.(*[]entity.Exchange)
 			*ptr = []entity.Exchange{
-				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: 5.25},
+				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.25")},
 			}
 			return nil
 		})
@@ -105,8 +115,8 @@ func TestKsqlExchangeService_ListExchanges_WithTargetFilter(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 			ptr := target.(*[]entity.Exchange)
 			*ptr = []entity.Exchange{
-				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: 5.25},
-				{ID: 2, BaseCurrency: "EUR", TargetCurrency: "BRL", Rate: 5.75},
+				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.25")},
+				{ID: 2, BaseCurrency: "EUR", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.75")},
 			}
 			return nil
 		})
@@ -137,7 +147,7 @@ func TestKsqlExchangeService_ListExchanges_WithBothFilters(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 			ptr := target.(*[]entity.Exchange)
 			*ptr = []entity.Exchange{
-				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: 5.25},
+				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.25")},
 			}
 			return nil
 		})
@@ -176,7 +186,9 @@ func TestKsqlExchangeService_ListExchanges_Error(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
-func TestKsqlExchangeService_ReceiveExchangeRate_CreateNew(t *testing.T) {
+const upsertExchangeQuery = "INSERT INTO exchanges (base_currency, target_currency, rate, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (base_currency, target_currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.created_at RETURNING id"
+
+func TestKsqlExchangeService_ListExchangesAsOf(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -185,41 +197,87 @@ func TestKsqlExchangeService_ReceiveExchangeRate_CreateNew(t *testing.T) {
 	service := NewKSQLExchangeService(mockDB)
 
 	ctx := context.Background()
-	sourceCurrency := "USD"
-	targetCurrency := "BRL"
-	rate := 5.25
+	asOf := time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	expectedQuery := "SELECT e.id, e.base_currency, e.target_currency, r.rate, r.created_at AS observed_at FROM exchanges e JOIN exchange_rates r ON r.exchange_id = e.id WHERE r.id = (SELECT l.id FROM exchange_rates l WHERE l.exchange_id = e.id AND l.created_at <= $1 ORDER BY l.created_at DESC, l.id DESC LIMIT 1) AND ($2 = '' OR e.base_currency = $2) AND ($3 = '' OR e.target_currency = $3) ORDER BY e.id"
 
-	// Mock getExchangeBySourceAndTarget to return not found
 	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2 LIMIT 1",
-			sourceCurrency, targetCurrency).
-		Return(infra.ErrNotFound)
+		Query(ctx, gomock.Any(), expectedQuery, asOf.UTC(), "USD", "").
+		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
+			ptr := target.(*[]entity.ExchangeSnapshot)
+			*ptr = []entity.ExchangeSnapshot{
+				{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("4.98"), ObservedAt: asOf.Add(-time.Hour)},
+			}
+			return nil
+		})
+
+	// Act
+	result, err := service.ListExchangesAsOf(ctx, "USD", "", asOf)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "4.98", result[0].Rate.String())
+}
+
+func TestKsqlExchangeService_ListExchangeRates(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	service := NewKSQLExchangeService(mockDB)
+
+	ctx := context.Background()
+	location := time.FixedZone("BRT", -3*60*60)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, location)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, location)
+	expectedQuery := "SELECT r.id, r.exchange_id, r.rate, r.spread, r.created_at FROM exchange_rates r JOIN exchanges e ON e.id = r.exchange_id WHERE e.base_currency = $1 AND e.target_currency = $2 AND r.created_at >= $3 AND r.created_at <= $4 ORDER BY r.created_at, r.id"
 
-	// Mock createExchange
 	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"INSERT INTO exchanges (base_currency, target_currency, rate) VALUES ($1, $2, $3) RETURNING id",
-			sourceCurrency, targetCurrency, rate).
+		Query(ctx, gomock.Any(), expectedQuery, "USD", "BRL", from.UTC(), to.UTC()).
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
-			ptr := target.(*infra.ReturningID[uint64])
-			ptr.ID = 1
+			ptr := target.(*[]entity.ExchangeRate)
+			*ptr = []entity.ExchangeRate{
+				{ID: 1, ExchangeID: 1, Rate: decimal.RequireFromString("5.25")},
+				{ID: 2, ExchangeID: 1, Rate: decimal.RequireFromString("5.30")},
+			}
 			return nil
 		})
 
-	// Mock createExchangeRate
+	// Act
+	result, err := service.ListExchangeRates(ctx, "USD", "BRL", from, to)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "5.25", result[0].Rate.String())
+}
+
+func TestKsqlExchangeService_ListExchangeRates_Error(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	service := NewKSQLExchangeService(mockDB)
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+
 	mockDB.EXPECT().
-		Exec(ctx, "INSERT INTO exchange_rates (exchange_id, rate) VALUES ($1, $2)", uint64(1), rate).
-		Return(mockResult{lastInsertId: 1, rowsAffected: 1}, nil)
+		Query(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(expectedError)
 
 	// Act
-	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate)
+	result, err := service.ListExchangeRates(ctx, "USD", "BRL", time.Now().Add(-time.Hour), time.Now())
 
 	// Assert
-	require.NoError(t, err)
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
 }
 
-func TestKsqlExchangeService_ReceiveExchangeRate_UpdateExisting(t *testing.T) {
+func TestKsqlExchangeService_ReceiveExchangeRate_Upsert(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -230,48 +288,33 @@ func TestKsqlExchangeService_ReceiveExchangeRate_UpdateExisting(t *testing.T) {
 	ctx := context.Background()
 	sourceCurrency := "USD"
 	targetCurrency := "BRL"
-	rate := 5.50
-	now := time.Now()
-
-	existingExchange := entity.Exchange{
-		ID:             1,
-		BaseCurrency:   sourceCurrency,
-		TargetCurrency: targetCurrency,
-		Rate:           5.25,
-		CreatedAt:      now.Add(-1 * time.Hour),
-		UpdatedAt:      &now,
-	}
-
-	// Mock getExchangeBySourceAndTarget to return existing exchange
+	rate := decimal.RequireFromString("5.25")
+	spread := decimal.NewNullDecimal(decimal.RequireFromString("0.002"))
+
+	expectTransaction(ctx, mockDB)
+
+	// Mock upsertExchange
 	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2 LIMIT 1",
-			sourceCurrency, targetCurrency).
+		QueryOne(ctx, gomock.Any(), upsertExchangeQuery, sourceCurrency, targetCurrency, rate, gomock.Any()).
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
-			ptr := target.(*entity.Exchange)
-			*ptr = existingExchange
+			ptr := target.(*infra.ReturningID[uint64])
+			ptr.ID = 1
 			return nil
 		})
 
-	// Mock updateExchange
-	mockDB.EXPECT().
-		Exec(ctx, "UPDATE exchanges SET rate = $1, updated_at = (now() at TIME ZONE 'UTC') WHERE id = $2",
-			rate, uint64(1)).
-		Return(mockResult{rowsAffected: 1}, nil)
-
 	// Mock createExchangeRate
 	mockDB.EXPECT().
-		Exec(ctx, "INSERT INTO exchange_rates (exchange_id, rate) VALUES ($1, $2)", uint64(1), rate).
+		Exec(ctx, "INSERT INTO exchange_rates (exchange_id, rate, spread, created_at) VALUES ($1, $2, $3, $4)", uint64(1), rate, spread, gomock.Any()).
 		Return(mockResult{lastInsertId: 1, rowsAffected: 1}, nil)
 
 	// Act
-	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate)
+	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate, spread)
 
 	// Assert
 	require.NoError(t, err)
 }
 
-func TestKsqlExchangeService_ReceiveExchangeRate_CreateExchangeError(t *testing.T) {
+func TestKsqlExchangeService_ReceiveExchangeRate_UpsertError(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -282,32 +325,26 @@ func TestKsqlExchangeService_ReceiveExchangeRate_CreateExchangeError(t *testing.
 	ctx := context.Background()
 	sourceCurrency := "USD"
 	targetCurrency := "BRL"
-	rate := 5.25
-	expectedError := errors.New("insert failed")
+	rate := decimal.RequireFromString("5.25")
+	spread := decimal.NullDecimal{}
+	expectedError := errors.New("upsert failed")
 
-	// Mock getExchangeBySourceAndTarget to return not found
-	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2 LIMIT 1",
-			sourceCurrency, targetCurrency).
-		Return(infra.ErrNotFound)
+	expectTransaction(ctx, mockDB)
 
-	// Mock createExchange with error
+	// Mock upsertExchange with error
 	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"INSERT INTO exchanges (base_currency, target_currency, rate) VALUES ($1, $2, $3) RETURNING id",
-			sourceCurrency, targetCurrency, rate).
+		QueryOne(ctx, gomock.Any(), upsertExchangeQuery, sourceCurrency, targetCurrency, rate, gomock.Any()).
 		Return(expectedError)
 
 	// Act
-	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate)
+	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate, spread)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 }
 
-func TestKsqlExchangeService_ReceiveExchangeRate_UpdateExchangeError(t *testing.T) {
+func TestKsqlExchangeService_ReceiveExchangeRate_CreateExchangeRateErrorRollsBack(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -318,38 +355,35 @@ func TestKsqlExchangeService_ReceiveExchangeRate_UpdateExchangeError(t *testing.
 	ctx := context.Background()
 	sourceCurrency := "USD"
 	targetCurrency := "BRL"
-	rate := 5.50
-	now := time.Now()
-	expectedError := errors.New("update failed")
-
-	existingExchange := entity.Exchange{
-		ID:             1,
-		BaseCurrency:   sourceCurrency,
-		TargetCurrency: targetCurrency,
-		Rate:           5.25,
-		CreatedAt:      now.Add(-1 * time.Hour),
-		UpdatedAt:      &now,
-	}
-
-	// Mock getExchangeBySourceAndTarget
+	rate := decimal.RequireFromString("5.50")
+	spread := decimal.NullDecimal{}
+	expectedError := errors.New("insert failed")
+
+	// The transaction must see the error returned by the history insert to roll back
+	mockDB.EXPECT().
+		Transaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx infra.DB) error) error {
+			err := fn(mockDB)
+			assert.Equal(t, expectedError, err)
+			return err
+		})
+
+	// Mock upsertExchange
 	mockDB.EXPECT().
-		QueryOne(ctx, gomock.Any(),
-			"SELECT * FROM exchanges WHERE base_currency = $1 AND target_currency = $2 LIMIT 1",
-			sourceCurrency, targetCurrency).
+		QueryOne(ctx, gomock.Any(), upsertExchangeQuery, sourceCurrency, targetCurrency, rate, gomock.Any()).
 		DoAndReturn(func(ctx context.Context, target interface{}, query string, args ...interface{}) error {
-			ptr := target.(*entity.Exchange)
-			*ptr = existingExchange
+			ptr := target.(*infra.ReturningID[uint64])
+			ptr.ID = 1
 			return nil
 		})
 
-	// Mock updateExchange with error
+	// Mock createExchangeRate with error
 	mockDB.EXPECT().
-		Exec(ctx, "UPDATE exchanges SET rate = $1, updated_at = (now() at TIME ZONE 'UTC') WHERE id = $2",
-			rate, uint64(1)).
+		Exec(ctx, "INSERT INTO exchange_rates (exchange_id, rate, spread, created_at) VALUES ($1, $2, $3, $4)", uint64(1), rate, spread, gomock.Any()).
 		Return(nil, expectedError)
 
 	// Act
-	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate)
+	err := service.ReceiveExchangeRate(ctx, sourceCurrency, targetCurrency, rate, spread)
 
 	// Assert
 	assert.Error(t, err)
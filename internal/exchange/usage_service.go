@@ -0,0 +1,42 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/infra"
+	"time"
+)
+
+type ksqlProviderUsageService struct {
+	db infra.DB
+}
+
+func (k ksqlProviderUsageService) RecordProviderCalls(ctx context.Context, provider string, at time.Time, calls int64) error {
+	_, err := k.db.Exec(ctx, `INSERT INTO provider_usage (provider, month, calls) VALUES ($1, $2, $3) ON CONFLICT (provider, month) DO UPDATE SET calls = provider_usage.calls + EXCLUDED.calls`, provider, entity.UsageMonth(at), calls)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (k ksqlProviderUsageService) CountProviderCalls(ctx context.Context, provider string, at time.Time) (int64, error) {
+	var usage entity.ProviderUsage
+	err := k.db.QueryOne(ctx, &usage, `SELECT provider, month, calls FROM provider_usage WHERE provider = $1 AND month = $2`, provider, entity.UsageMonth(at))
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return usage.Calls, nil
+}
+
+func NewKSQLProviderUsageService(db infra.DB) entity.ProviderUsageService {
+	return &ksqlProviderUsageService{
+		db: db,
+	}
+}
@@ -0,0 +1,115 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"time"
+)
+
+type backfillExchangeRatesUseCase struct {
+	exchangeService    entity.ExchangeService
+	exchangeRateClient exchangerate.Client
+}
+
+func (b *backfillExchangeRatesUseCase) Execute(ctx context.Context, req entity.BackfillExchangeRatesRequest) (*entity.BackfillExchangeRatesResponse, error) {
+	from, to := utcDay(req.From), utcDay(req.To)
+	if to.Before(from) {
+		var validation entity.ValidationError
+		validation.Add("to", "must not be before from")
+		return nil, validation.OrNil()
+	}
+
+	recorded, err := b.recordedDays(ctx, req.SourceCurrency, req.TargetCurrencies, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entity.BackfillExchangeRatesResponse{}
+	var errs []error
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		missing := make([]string, 0, len(req.TargetCurrencies))
+		for _, target := range req.TargetCurrencies {
+			if recorded[target][day] {
+				response.Skipped++
+				continue
+			}
+
+			missing = append(missing, target)
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		rates, err := b.exchangeRateClient.GetHistoricalRates(ctx, req.SourceCurrency, missing, day)
+		if errors.Is(err, exchangerate.ErrHistoricalUnsupported) {
+			// No other day would do better.
+			errs = append(errs, err)
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", day.Format(time.DateOnly), err))
+		}
+
+		for _, target := range missing {
+			rate, ok := rates[target]
+			if !ok {
+				continue
+			}
+
+			created, err := b.exchangeService.BackfillExchangeRate(ctx, req.SourceCurrency, target, rate.Rate, day)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %s-%s: %w", day.Format(time.DateOnly), req.SourceCurrency, target, err))
+				continue
+			}
+
+			if created {
+				response.Created++
+			} else {
+				response.Skipped++
+			}
+		}
+	}
+
+	return response, errors.Join(errs...)
+}
+
+// recordedDays returns the days each target already has a rate on, between from and to.
+func (b *backfillExchangeRatesUseCase) recordedDays(ctx context.Context, source string, targets []string, from, to time.Time) (map[string]map[time.Time]bool, error) {
+	recorded := make(map[string]map[time.Time]bool, len(targets))
+	for _, target := range targets {
+		exchangeRates, err := b.exchangeService.ListExchangeRates(ctx, source, target, from, to.AddDate(0, 0, 1).Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+
+		recorded[target] = make(map[time.Time]bool, len(exchangeRates))
+		for _, exchangeRate := range exchangeRates {
+			recorded[target][utcDay(exchangeRate.CreatedAt)] = true
+		}
+	}
+
+	return recorded, nil
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NewBackfillExchangeRatesUseCase returns a use case filling past daily rates
+// from the providers' historical rates.
+func NewBackfillExchangeRatesUseCase(exchangeService entity.ExchangeService, exchangeRateClient exchangerate.Client) entity.BackfillExchangeRatesUseCase {
+	return &backfillExchangeRatesUseCase{
+		exchangeService:    exchangeService,
+		exchangeRateClient: exchangeRateClient,
+	}
+}
@@ -0,0 +1,158 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	clientMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	entityMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var (
+	jan1 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan2 = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+)
+
+func TestBackfillExchangeRatesUseCase_Execute_FillsMissingDays(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewBackfillExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	endOfRange := jan2.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	mockService.EXPECT().
+		ListExchangeRates(ctx, "USD", "BRL", jan1, endOfRange).
+		Return([]entity.ExchangeRate{{Rate: decimal.RequireFromString("4.85"), CreatedAt: jan1.Add(15 * time.Hour)}}, nil)
+	mockService.EXPECT().
+		ListExchangeRates(ctx, "USD", "EUR", jan1, endOfRange).
+		Return(nil, nil)
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			GetHistoricalRates(ctx, "USD", []string{"EUR"}, jan1).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{"EUR": {Rate: decimal.RequireFromString("0.91")}}, nil),
+		mockService.EXPECT().
+			BackfillExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.91"), jan1).
+			Return(true, nil),
+		mockClient.EXPECT().
+			GetHistoricalRates(ctx, "USD", []string{"BRL", "EUR"}, jan2).
+			Return(map[string]*exchangerate.GetExchangeRateResponse{
+				"BRL": {Rate: decimal.RequireFromString("4.90")},
+				"EUR": {Rate: decimal.RequireFromString("0.92")},
+			}, nil),
+		mockService.EXPECT().
+			BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("4.90"), jan2).
+			Return(true, nil),
+		mockService.EXPECT().
+			BackfillExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), jan2).
+			Return(false, nil), // Synced since the range was listed
+	)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.BackfillExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL", "EUR"},
+		From:             jan1.Add(10 * time.Hour),
+		To:               jan2,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &entity.BackfillExchangeRatesResponse{Created: 2, Skipped: 2}, result)
+}
+
+func TestBackfillExchangeRatesUseCase_Execute_ClientErrorKeepsGoing(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewBackfillExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	expectedError := errors.New("bad gateway")
+
+	mockService.EXPECT().ListExchangeRates(ctx, "USD", "BRL", jan1, gomock.Any()).Return(nil, nil)
+	mockClient.EXPECT().GetHistoricalRates(ctx, "USD", []string{"BRL"}, jan1).Return(nil, expectedError)
+	mockClient.EXPECT().
+		GetHistoricalRates(ctx, "USD", []string{"BRL"}, jan2).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{"BRL": {Rate: decimal.RequireFromString("4.90")}}, nil)
+	mockService.EXPECT().BackfillExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("4.90"), jan2).Return(true, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.BackfillExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL"},
+		From:             jan1,
+		To:               jan2,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.ErrorContains(t, err, "2024-01-01")
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Created)
+}
+
+func TestBackfillExchangeRatesUseCase_Execute_HistoricalUnsupportedStops(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewBackfillExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+
+	mockService.EXPECT().ListExchangeRates(ctx, "USD", "BRL", jan1, gomock.Any()).Return(nil, nil)
+	mockClient.EXPECT().GetHistoricalRates(ctx, "USD", []string{"BRL"}, jan1).Return(nil, exchangerate.ErrHistoricalUnsupported)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.BackfillExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL"},
+		From:             jan1,
+		To:               jan2,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, exchangerate.ErrHistoricalUnsupported)
+	require.NotNil(t, result)
+	assert.Zero(t, result.Created)
+}
+
+func TestBackfillExchangeRatesUseCase_Execute_InvalidRange(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := NewBackfillExchangeRatesUseCase(entityMocks.NewMockExchangeService(ctrl), clientMocks.NewMockClient(ctrl))
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.BackfillExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL"},
+		From:             jan2,
+		To:               jan1,
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *entity.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "to", validationErr.Fields[0].Field)
+}
@@ -0,0 +1,135 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/shopspring/decimal"
+)
+
+// conversionRatePrecision is the scale of inverted and effective rates, matching
+// the scale rates are stored with.
+const conversionRatePrecision = 15
+
+type currencyPair struct {
+	source string
+	target string
+}
+
+type convertUseCase struct {
+	exchangeService entity.ExchangeService
+	pivotCurrencies []string
+}
+
+func (s *convertUseCase) Execute(ctx context.Context, req entity.ConvertRequest) (*entity.ConvertResponse, error) {
+	err := normalizeCurrencies(
+		currencyField{name: "from", code: &req.From},
+		currencyField{name: "to", code: &req.To},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	minorUnits, ok := entity.CurrencyMinorUnits(req.To)
+	if !ok {
+		var validation entity.ValidationError
+		validation.Add("to", fmt.Sprintf("%q has no minor units, so converted amounts can't be rounded", req.To))
+		return nil, validation.OrNil()
+	}
+
+	steps, err := s.conversionSteps(ctx, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := decimal.NewFromInt(1)
+	path := []string{req.From}
+	for _, step := range steps {
+		rate = rate.Mul(step.Rate)
+		path = append(path, step.To)
+	}
+
+	return &entity.ConvertResponse{
+		From:   req.From,
+		To:     req.To,
+		Amount: req.Amount,
+		Result: req.Amount.Mul(rate).Round(minorUnits),
+		Rate:   rate.Round(conversionRatePrecision),
+		Path:   path,
+		Rates:  steps,
+	}, nil
+}
+
+// conversionSteps prefers the direct pair, then its inverse, then the first
+// pivot currency linked to both sides.
+func (s *convertUseCase) conversionSteps(ctx context.Context, from, to string) ([]entity.ConversionStep, error) {
+	if from == to {
+		return []entity.ConversionStep{}, nil
+	}
+
+	exchanges, err := s.exchangeService.ListExchanges(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	byPair := make(map[currencyPair]entity.Exchange, len(exchanges))
+	for _, exchange := range exchanges {
+		byPair[currencyPair{source: exchange.BaseCurrency, target: exchange.TargetCurrency}] = exchange
+	}
+
+	if step, ok := conversionStep(byPair, from, to); ok {
+		return []entity.ConversionStep{step}, nil
+	}
+
+	for _, pivot := range s.pivotCurrencies {
+		if pivot == from || pivot == to {
+			continue
+		}
+
+		first, ok := conversionStep(byPair, from, pivot)
+		if !ok {
+			continue
+		}
+
+		second, ok := conversionStep(byPair, pivot, to)
+		if !ok {
+			continue
+		}
+
+		return []entity.ConversionStep{first, second}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s-%s", entity.ErrConversionPathNotFound, from, to)
+}
+
+// conversionStep converts between two currencies with their stored rate or,
+// failing that, the reciprocal of the opposite pair's rate.
+func conversionStep(byPair map[currencyPair]entity.Exchange, from, to string) (entity.ConversionStep, bool) {
+	if exchange, ok := byPair[currencyPair{source: from, target: to}]; ok {
+		return entity.ConversionStep{
+			From:            from,
+			To:              to,
+			Rate:            exchange.Rate,
+			LastAcquisition: lastAcquisition(exchange),
+		}, true
+	}
+
+	if exchange, ok := byPair[currencyPair{source: to, target: from}]; ok && !exchange.Rate.IsZero() {
+		return entity.ConversionStep{
+			From:            from,
+			To:              to,
+			Rate:            decimal.NewFromInt(1).DivRound(exchange.Rate, conversionRatePrecision),
+			Inverted:        true,
+			LastAcquisition: lastAcquisition(exchange),
+		}, true
+	}
+
+	return entity.ConversionStep{}, false
+}
+
+func NewConvertUseCase(exchangeService entity.ExchangeService, pivotCurrencies []string) entity.ConvertUseCase {
+	return &convertUseCase{
+		exchangeService: exchangeService,
+		pivotCurrencies: pivotCurrencies,
+	}
+}
@@ -0,0 +1,315 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var convertExchanges = []entity.Exchange{
+	{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.00")},
+	{ID: 2, BaseCurrency: "EUR", TargetCurrency: "USD", Rate: decimal.RequireFromString("1.10")},
+	{ID: 3, BaseCurrency: "USD", TargetCurrency: "JPY", Rate: decimal.RequireFromString("150.123")},
+	{ID: 4, BaseCurrency: "GBP", TargetCurrency: "BHD", Rate: decimal.RequireFromString("0.4789")},
+	{ID: 5, BaseCurrency: "XAU", TargetCurrency: "USD", Rate: decimal.RequireFromString("2350.5")},
+}
+
+func TestConvertUseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name           string
+		from           string
+		to             string
+		amount         string
+		expectedResult string
+		expectedRate   string
+		expectedPath   []string
+		expectedSteps  []bool // Inverted flag of each step
+	}{
+		{
+			name:           "direct pair",
+			from:           "USD",
+			to:             "BRL",
+			amount:         "123.45",
+			expectedResult: "617.25",
+			expectedRate:   "5",
+			expectedPath:   []string{"USD", "BRL"},
+			expectedSteps:  []bool{false},
+		},
+		{
+			name:           "inverse pair",
+			from:           "BRL",
+			to:             "USD",
+			amount:         "100",
+			expectedResult: "20",
+			expectedRate:   "0.2",
+			expectedPath:   []string{"BRL", "USD"},
+			expectedSteps:  []bool{true},
+		},
+		{
+			name:           "through pivot currency",
+			from:           "EUR",
+			to:             "BRL",
+			amount:         "123.45",
+			expectedResult: "678.98",
+			expectedRate:   "5.5",
+			expectedPath:   []string{"EUR", "USD", "BRL"},
+			expectedSteps:  []bool{false, false},
+		},
+		{
+			name:           "through pivot currency with inverse leg",
+			from:           "BRL",
+			to:             "EUR",
+			amount:         "1000",
+			expectedResult: "181.82",
+			expectedRate:   "0.181818181818182",
+			expectedPath:   []string{"BRL", "USD", "EUR"},
+			expectedSteps:  []bool{true, true},
+		},
+		{
+			name:           "rounds to zero minor units",
+			from:           "USD",
+			to:             "JPY",
+			amount:         "10.50",
+			expectedResult: "1576",
+			expectedRate:   "150.123",
+			expectedPath:   []string{"USD", "JPY"},
+			expectedSteps:  []bool{false},
+		},
+		{
+			name:           "rounds to three minor units",
+			from:           "GBP",
+			to:             "BHD",
+			amount:         "10.01",
+			expectedResult: "4.794",
+			expectedRate:   "0.4789",
+			expectedPath:   []string{"GBP", "BHD"},
+			expectedSteps:  []bool{false},
+		},
+		{
+			name:           "from a currency without minor units",
+			from:           "XAU",
+			to:             "USD",
+			amount:         "0.5",
+			expectedResult: "1175.25",
+			expectedRate:   "2350.5",
+			expectedPath:   []string{"XAU", "USD"},
+			expectedSteps:  []bool{false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockExchangeService(ctrl)
+			useCase := NewConvertUseCase(mockService, []string{"EUR", "USD"})
+
+			ctx := context.Background()
+			mockService.EXPECT().
+				ListExchanges(ctx, "", "").
+				Return(convertExchanges, nil)
+
+			// Act
+			result, err := useCase.Execute(ctx, entity.ConvertRequest{
+				From:   tc.from,
+				To:     tc.to,
+				Amount: decimal.RequireFromString(tc.amount),
+			})
+
+			// Assert
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, tc.expectedResult, result.Result.String())
+			assert.Equal(t, tc.expectedRate, result.Rate.String())
+			assert.Equal(t, tc.expectedPath, result.Path)
+			require.Len(t, result.Rates, len(tc.expectedSteps))
+			for i, inverted := range tc.expectedSteps {
+				assert.Equal(t, tc.expectedPath[i], result.Rates[i].From)
+				assert.Equal(t, tc.expectedPath[i+1], result.Rates[i].To)
+				assert.Equal(t, inverted, result.Rates[i].Inverted)
+			}
+		})
+	}
+}
+
+func TestConvertUseCase_Execute_SameCurrency(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewConvertUseCase(mockService, []string{"USD"})
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+		From:   "BRL",
+		To:     "BRL",
+		Amount: decimal.RequireFromString("10.005"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "10.01", result.Result.String())
+	assert.Equal(t, "1", result.Rate.String())
+	assert.Equal(t, []string{"BRL"}, result.Path)
+	assert.Len(t, result.Rates, 0)
+}
+
+func TestConvertUseCase_Execute_ReportsLastAcquisition(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewConvertUseCase(mockService, nil)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	mockService.EXPECT().
+		ListExchanges(gomock.Any(), "", "").
+		Return([]entity.Exchange{
+			{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5"), CreatedAt: createdAt, UpdatedAt: &updatedAt},
+		}, nil)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+		From:   "BRL",
+		To:     "USD",
+		Amount: decimal.RequireFromString("1"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Rates, 1)
+	assert.Equal(t, updatedAt, result.Rates[0].LastAcquisition)
+}
+
+func TestConvertUseCase_Execute_NormalizesCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewConvertUseCase(mockService, nil)
+
+	mockService.EXPECT().
+		ListExchanges(gomock.Any(), "", "").
+		Return(convertExchanges, nil)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+		From:   "usd",
+		To:     " brl",
+		Amount: decimal.RequireFromString("2"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "USD", result.From)
+	assert.Equal(t, "BRL", result.To)
+	assert.Equal(t, "10", result.Result.String())
+}
+
+func TestConvertUseCase_Execute_InvalidCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewConvertUseCase(mockService, nil)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+		From:   "abc",
+		To:     "XYZ",
+		Amount: decimal.RequireFromString("1"),
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *entity.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entity.FieldError{
+		{Field: "from", Message: `"ABC" is not an ISO 4217 currency code`},
+		{Field: "to", Message: `"XYZ" is not an ISO 4217 currency code`},
+	}, validationErr.Fields)
+}
+
+func TestConvertUseCase_Execute_TargetWithoutMinorUnits(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewConvertUseCase(mockService, nil)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+		From:   "USD",
+		To:     "xau",
+		Amount: decimal.RequireFromString("1"),
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *entity.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entity.FieldError{
+		{Field: "to", Message: `"XAU" has no minor units, so converted amounts can't be rounded`},
+	}, validationErr.Fields)
+}
+
+func TestConvertUseCase_Execute_Errors(t *testing.T) {
+	serviceError := errors.New("database error")
+
+	testCases := []struct {
+		name          string
+		from          string
+		to            string
+		exchanges     []entity.Exchange
+		serviceError  error
+		expectList    bool
+		expectedError error
+	}{
+		{"no conversion path", "GBP", "BRL", convertExchanges, nil, true, entity.ErrConversionPathNotFound},
+		{"service error", "USD", "BRL", nil, serviceError, true, serviceError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockExchangeService(ctrl)
+			useCase := NewConvertUseCase(mockService, []string{"USD", "EUR"})
+
+			if tc.expectList {
+				mockService.EXPECT().
+					ListExchanges(gomock.Any(), "", "").
+					Return(tc.exchanges, tc.serviceError)
+			}
+
+			// Act
+			result, err := useCase.Execute(context.Background(), entity.ConvertRequest{
+				From:   tc.from,
+				To:     tc.to,
+				Amount: decimal.RequireFromString("1"),
+			})
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
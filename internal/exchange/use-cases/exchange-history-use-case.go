@@ -0,0 +1,65 @@
+package use_cases
+
+import (
+	"context"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/shopspring/decimal"
+)
+
+// averageRatePrecision matches the scale rates are stored with.
+const averageRatePrecision = 15
+
+type exchangeHistoryUseCase struct {
+	exchangeService entity.ExchangeService
+}
+
+func (s *exchangeHistoryUseCase) Execute(ctx context.Context, req entity.ExchangeHistoryRequest) (*entity.ExchangeHistoryResponse, error) {
+	err := normalizeCurrencies(
+		currencyField{name: "source", code: &req.SourceCurrency},
+		currencyField{name: "target", code: &req.TargetCurrency},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRates, err := s.exchangeService.ListExchangeRates(ctx, req.SourceCurrency, req.TargetCurrency, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []entity.ExchangeHistoryPoint{}
+	var sum decimal.Decimal
+	for _, exchangeRate := range exchangeRates {
+		bucket := req.Interval.Truncate(exchangeRate.CreatedAt)
+		if len(points) == 0 || !points[len(points)-1].Time.Equal(bucket) {
+			points = append(points, entity.ExchangeHistoryPoint{Time: bucket})
+			sum = decimal.Zero
+		}
+
+		// Rates come oldest first, so the last one seen is the bucket's last value.
+		point := &points[len(points)-1]
+		point.Samples++
+		sum = sum.Add(exchangeRate.Rate)
+		if req.Aggregation == entity.HistoryAggregationAverage {
+			point.Rate = sum.DivRound(decimal.NewFromInt(int64(point.Samples)), averageRatePrecision)
+		} else {
+			point.Rate = exchangeRate.Rate
+		}
+	}
+
+	return &entity.ExchangeHistoryResponse{
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: req.TargetCurrency,
+		From:           req.From,
+		To:             req.To,
+		Interval:       req.Interval,
+		Aggregation:    req.Aggregation,
+		Points:         points,
+	}, nil
+}
+
+func NewExchangeHistoryUseCase(exchangeService entity.ExchangeService) entity.ExchangeHistoryUseCase {
+	return &exchangeHistoryUseCase{
+		exchangeService: exchangeService,
+	}
+}
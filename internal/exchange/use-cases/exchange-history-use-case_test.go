@@ -0,0 +1,163 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+// historyRates are four observations spread over two days, oldest first.
+func historyRates() []entity.ExchangeRate {
+	return []entity.ExchangeRate{
+		{ID: 1, ExchangeID: 1, Rate: decimal.RequireFromString("5.00"), CreatedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, ExchangeID: 1, Rate: decimal.RequireFromString("5.10"), CreatedAt: time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)},
+		{ID: 3, ExchangeID: 1, Rate: decimal.RequireFromString("5.30"), CreatedAt: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)},
+		{ID: 4, ExchangeID: 1, Rate: decimal.RequireFromString("5.40"), CreatedAt: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestExchangeHistoryUseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name           string
+		interval       entity.HistoryInterval
+		aggregation    entity.HistoryAggregation
+		expectedTimes  []time.Time
+		expectedRates  []string
+		expectedCounts []int
+	}{
+		{
+			name:        "hourly last",
+			interval:    entity.HistoryIntervalHour,
+			aggregation: entity.HistoryAggregationLast,
+			expectedTimes: []time.Time{
+				time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+			},
+			expectedRates:  []string{"5.1", "5.3", "5.4"},
+			expectedCounts: []int{2, 1, 1},
+		},
+		{
+			name:        "daily average",
+			interval:    entity.HistoryIntervalDay,
+			aggregation: entity.HistoryAggregationAverage,
+			expectedTimes: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			expectedRates:  []string{"5.133333333333333", "5.4"},
+			expectedCounts: []int{3, 1},
+		},
+		{
+			name:        "weekly last starts on monday",
+			interval:    entity.HistoryIntervalWeek,
+			aggregation: entity.HistoryAggregationLast,
+			expectedTimes: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // 2024-01-01 is a Monday
+			},
+			expectedRates:  []string{"5.4"},
+			expectedCounts: []int{4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockExchangeService(ctrl)
+			useCase := NewExchangeHistoryUseCase(mockService)
+
+			ctx := context.Background()
+			req := entity.ExchangeHistoryRequest{
+				SourceCurrency: "USD",
+				TargetCurrency: "BRL",
+				From:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:             time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				Interval:       tc.interval,
+				Aggregation:    tc.aggregation,
+			}
+
+			mockService.EXPECT().
+				ListExchangeRates(ctx, "USD", "BRL", req.From, req.To).
+				Return(historyRates(), nil)
+
+			// Act
+			result, err := useCase.Execute(ctx, req)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, tc.interval, result.Interval)
+			assert.Equal(t, tc.aggregation, result.Aggregation)
+			require.Len(t, result.Points, len(tc.expectedRates))
+			for i, point := range result.Points {
+				assert.Equal(t, tc.expectedTimes[i], point.Time)
+				assert.Equal(t, tc.expectedRates[i], point.Rate.String())
+				assert.Equal(t, tc.expectedCounts[i], point.Samples)
+			}
+		})
+	}
+}
+
+func TestExchangeHistoryUseCase_Execute_EmptyHistory(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewExchangeHistoryUseCase(mockService)
+
+	ctx := context.Background()
+	req := entity.ExchangeHistoryRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		Interval:       entity.HistoryIntervalDay,
+		Aggregation:    entity.HistoryAggregationLast,
+	}
+
+	mockService.EXPECT().
+		ListExchangeRates(ctx, "USD", "BRL", req.From, req.To).
+		Return([]entity.ExchangeRate{}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, req)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.NotNil(t, result.Points) // Serialized as [] instead of null
+	assert.Len(t, result.Points, 0)
+}
+
+func TestExchangeHistoryUseCase_Execute_ServiceError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewExchangeHistoryUseCase(mockService)
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+
+	mockService.EXPECT().
+		ListExchangeRates(ctx, "USD", "BRL", gomock.Any(), gomock.Any()).
+		Return(nil, expectedError)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.ExchangeHistoryRequest{SourceCurrency: "USD", TargetCurrency: "BRL"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
@@ -0,0 +1,44 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+)
+
+type getExchangeUseCase struct {
+	listExchangesUseCase entity.ListExchangesUseCase
+}
+
+func (s *getExchangeUseCase) Execute(ctx context.Context, req entity.GetExchangeRequest) (*entity.ExchangeResponse, error) {
+	err := normalizeCurrencies(
+		currencyField{name: "source", code: &req.SourceCurrency},
+		currencyField{name: "target", code: &req.TargetCurrency},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges, err := s.listExchangesUseCase.Execute(ctx, entity.ListExchangesRequest{
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: req.TargetCurrency,
+		AsOf:           req.AsOf,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*exchanges) == 0 {
+		return nil, fmt.Errorf("%w: %s-%s", entity.ErrExchangeNotFound, req.SourceCurrency, req.TargetCurrency)
+	}
+
+	return &(*exchanges)[0], nil
+}
+
+// NewGetExchangeUseCase looks up a single pair through ListExchanges, so both
+// share the same mapping and as_of handling.
+func NewGetExchangeUseCase(exchangeService entity.ExchangeService) entity.GetExchangeUseCase {
+	return &getExchangeUseCase{
+		listExchangesUseCase: NewListExchangesUseCase(exchangeService),
+	}
+}
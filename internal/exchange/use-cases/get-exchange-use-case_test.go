@@ -0,0 +1,150 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestGetExchangeUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewGetExchangeUseCase(mockService)
+
+	ctx := context.Background()
+	now := time.Now()
+	mockService.EXPECT().
+		ListExchanges(ctx, "USD", "BRL").
+		Return([]entity.Exchange{
+			{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("5.25"), CreatedAt: now},
+		}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.GetExchangeRequest{
+		SourceCurrency: "usd",
+		TargetCurrency: "brl",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, uint64(1), result.ID)
+	assert.Equal(t, "USD", result.SourceCurrency)
+	assert.Equal(t, "BRL", result.TargetCurrency)
+	assert.Equal(t, "5.25", result.Rate.String())
+	assert.Equal(t, now, result.LastAcquisition)
+}
+
+func TestGetExchangeUseCase_Execute_AsOf(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewGetExchangeUseCase(mockService)
+
+	ctx := context.Background()
+	asOf := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().
+		ListExchangesAsOf(ctx, "USD", "BRL", asOf).
+		Return([]entity.ExchangeSnapshot{
+			{ID: 1, BaseCurrency: "USD", TargetCurrency: "BRL", Rate: decimal.RequireFromString("4.98"), ObservedAt: asOf.Add(-time.Hour)},
+		}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.GetExchangeRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		AsOf:           &asOf,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "4.98", result.Rate.String())
+	assert.Equal(t, asOf.Add(-time.Hour), result.LastAcquisition)
+	assert.Equal(t, &asOf, result.AsOf)
+}
+
+func TestGetExchangeUseCase_Execute_NotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewGetExchangeUseCase(mockService)
+
+	ctx := context.Background()
+	mockService.EXPECT().
+		ListExchanges(ctx, "USD", "JPY").
+		Return([]entity.Exchange{}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.GetExchangeRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "JPY",
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, entity.ErrExchangeNotFound)
+	assert.EqualError(t, err, "exchange not found: USD-JPY")
+}
+
+func TestGetExchangeUseCase_Execute_InvalidCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewGetExchangeUseCase(mockService)
+
+	// Act
+	result, err := useCase.Execute(context.Background(), entity.GetExchangeRequest{
+		SourceCurrency: "",
+		TargetCurrency: "dollar",
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *entity.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entity.FieldError{
+		{Field: "source", Message: "is required"},
+		{Field: "target", Message: `"DOLLAR" is not an ISO 4217 currency code`},
+	}, validationErr.Fields)
+}
+
+func TestGetExchangeUseCase_Execute_ServiceError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewGetExchangeUseCase(mockService)
+
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+	mockService.EXPECT().
+		ListExchanges(ctx, "USD", "BRL").
+		Return(nil, expectedError)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.GetExchangeRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
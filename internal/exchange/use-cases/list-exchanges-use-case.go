@@ -3,6 +3,7 @@ package use_cases
 import (
 	"context"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"time"
 )
 
 type listExchangesUseCase struct {
@@ -10,6 +11,18 @@ type listExchangesUseCase struct {
 }
 
 func (s *listExchangesUseCase) Execute(ctx context.Context, req entity.ListExchangesRequest) (*entity.ListExchangesResponse, error) {
+	err := normalizeCurrencies(
+		currencyField{name: "source", code: &req.SourceCurrency, optional: true},
+		currencyField{name: "target", code: &req.TargetCurrency, optional: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AsOf != nil {
+		return s.executeAsOf(ctx, req)
+	}
+
 	exchanges, err := s.exchangeService.ListExchanges(ctx, req.SourceCurrency, req.TargetCurrency)
 	if err != nil {
 		return nil, err
@@ -17,23 +30,48 @@ func (s *listExchangesUseCase) Execute(ctx context.Context, req entity.ListExcha
 
 	exchangesResponse := make(entity.ListExchangesResponse, len(exchanges))
 	for i, exchange := range exchanges {
-		lastAcquisition := exchange.CreatedAt
-		if exchange.UpdatedAt != nil {
-			lastAcquisition = *exchange.UpdatedAt
-		}
-
 		exchangesResponse[i] = entity.ExchangeResponse{
 			ID:              exchange.ID,
 			SourceCurrency:  exchange.BaseCurrency,
 			TargetCurrency:  exchange.TargetCurrency,
 			Rate:            exchange.Rate,
-			LastAcquisition: lastAcquisition,
+			LastAcquisition: lastAcquisition(exchange),
 		}
 	}
 
 	return &exchangesResponse, nil
 }
 
+func (s *listExchangesUseCase) executeAsOf(ctx context.Context, req entity.ListExchangesRequest) (*entity.ListExchangesResponse, error) {
+	snapshots, err := s.exchangeService.ListExchangesAsOf(ctx, req.SourceCurrency, req.TargetCurrency, *req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangesResponse := make(entity.ListExchangesResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		exchangesResponse[i] = entity.ExchangeResponse{
+			ID:              snapshot.ID,
+			SourceCurrency:  snapshot.BaseCurrency,
+			TargetCurrency:  snapshot.TargetCurrency,
+			Rate:            snapshot.Rate,
+			LastAcquisition: snapshot.ObservedAt,
+			AsOf:            req.AsOf,
+		}
+	}
+
+	return &exchangesResponse, nil
+}
+
+// lastAcquisition is when the exchange's current rate was received.
+func lastAcquisition(exchange entity.Exchange) time.Time {
+	if exchange.UpdatedAt != nil {
+		return *exchange.UpdatedAt
+	}
+
+	return exchange.CreatedAt
+}
+
 func NewListExchangesUseCase(exchangeService entity.ExchangeService) entity.ListExchangesUseCase {
 	return &listExchangesUseCase{
 		exchangeService: exchangeService,
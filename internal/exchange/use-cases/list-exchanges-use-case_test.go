@@ -2,8 +2,10 @@ package use_cases
 
 import (
 	"context"
+	"errors"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -31,7 +33,7 @@ func TestListExchangesUseCase_Execute_Success(t *testing.T) {
 			ID:             1,
 			BaseCurrency:   "USD",
 			TargetCurrency: "BRL",
-			Rate:           5.25,
+			Rate:           decimal.RequireFromString("5.25"),
 			CreatedAt:      now,
 			UpdatedAt:      &now,
 		},
@@ -53,7 +55,7 @@ func TestListExchangesUseCase_Execute_Success(t *testing.T) {
 	assert.Equal(t, uint64(1), response.ID)
 	assert.Equal(t, "USD", response.SourceCurrency)
 	assert.Equal(t, "BRL", response.TargetCurrency)
-	assert.Equal(t, 5.25, response.Rate)
+	assert.Equal(t, "5.25", response.Rate.String())
 	assert.Equal(t, now, response.LastAcquisition)
 }
 
@@ -77,7 +79,7 @@ func TestListExchangesUseCase_Execute_WithoutFilters(t *testing.T) {
 			ID:             1,
 			BaseCurrency:   "USD",
 			TargetCurrency: "BRL",
-			Rate:           5.25,
+			Rate:           decimal.RequireFromString("5.25"),
 			CreatedAt:      now,
 			UpdatedAt:      nil,
 		},
@@ -85,7 +87,7 @@ func TestListExchangesUseCase_Execute_WithoutFilters(t *testing.T) {
 			ID:             2,
 			BaseCurrency:   "EUR",
 			TargetCurrency: "BRL",
-			Rate:           5.75,
+			Rate:           decimal.RequireFromString("5.75"),
 			CreatedAt:      now,
 			UpdatedAt:      nil,
 		},
@@ -107,7 +109,7 @@ func TestListExchangesUseCase_Execute_WithoutFilters(t *testing.T) {
 	firstExchange := (*result)[0]
 	assert.Equal(t, "USD", firstExchange.SourceCurrency)
 	assert.Equal(t, "BRL", firstExchange.TargetCurrency)
-	assert.Equal(t, 5.25, firstExchange.Rate)
+	assert.Equal(t, "5.25", firstExchange.Rate.String())
 	assert.Equal(t, now, firstExchange.LastAcquisition) // Should use CreatedAt when UpdatedAt is nil
 }
 
@@ -148,12 +150,12 @@ func TestListExchangesUseCase_Execute_EmptyResult(t *testing.T) {
 	ctx := context.Background()
 	req := entity.ListExchangesRequest{
 		SourceCurrency: "USD",
-		TargetCurrency: "XYZ",
+		TargetCurrency: "JPY",
 	}
 
 	emptyExchanges := []entity.Exchange{}
 	mockService.EXPECT().
-		ListExchanges(ctx, "USD", "XYZ").
+		ListExchanges(ctx, "USD", "JPY").
 		Return(emptyExchanges, nil)
 
 	// Act
@@ -165,6 +167,59 @@ func TestListExchangesUseCase_Execute_EmptyResult(t *testing.T) {
 	assert.Len(t, *result, 0)
 }
 
+func TestListExchangesUseCase_Execute_NormalizesCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewListExchangesUseCase(mockService)
+
+	ctx := context.Background()
+	req := entity.ListExchangesRequest{
+		SourceCurrency: "usd",
+		TargetCurrency: "",
+	}
+
+	mockService.EXPECT().
+		ListExchanges(ctx, "USD", "").
+		Return([]entity.Exchange{}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, req)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+}
+
+func TestListExchangesUseCase_Execute_InvalidCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewListExchangesUseCase(mockService)
+
+	ctx := context.Background()
+	req := entity.ListExchangesRequest{
+		SourceCurrency: "US",
+		TargetCurrency: "XYZ",
+	}
+
+	// Act
+	result, err := useCase.Execute(ctx, req)
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *entity.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []entity.FieldError{
+		{Field: "source", Message: `"US" is not an ISO 4217 currency code`},
+		{Field: "target", Message: `"XYZ" is not an ISO 4217 currency code`},
+	}, validationErr.Fields)
+}
+
 func TestListExchangesUseCase_Execute_UsesUpdatedAtWhenAvailable(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -186,7 +241,7 @@ func TestListExchangesUseCase_Execute_UsesUpdatedAtWhenAvailable(t *testing.T) {
 			ID:             1,
 			BaseCurrency:   "USD",
 			TargetCurrency: "BRL",
-			Rate:           5.25,
+			Rate:           decimal.RequireFromString("5.25"),
 			CreatedAt:      createdAt,
 			UpdatedAt:      &updatedAt,
 		},
@@ -207,3 +262,72 @@ func TestListExchangesUseCase_Execute_UsesUpdatedAtWhenAvailable(t *testing.T) {
 	response := (*result)[0]
 	assert.Equal(t, updatedAt, response.LastAcquisition) // Should use UpdatedAt when available
 }
+
+func TestListExchangesUseCase_Execute_AsOf(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewListExchangesUseCase(mockService)
+
+	ctx := context.Background()
+	asOf := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	req := entity.ListExchangesRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		AsOf:           &asOf,
+	}
+
+	observedAt := asOf.Add(-15 * time.Minute)
+	mockService.EXPECT().
+		ListExchangesAsOf(ctx, "USD", "BRL", asOf).
+		Return([]entity.ExchangeSnapshot{
+			{
+				ID:             1,
+				BaseCurrency:   "USD",
+				TargetCurrency: "BRL",
+				Rate:           decimal.RequireFromString("4.98"),
+				ObservedAt:     observedAt,
+			},
+		}, nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, req)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Len(t, *result, 1)
+
+	response := (*result)[0]
+	assert.Equal(t, "4.98", response.Rate.String())
+	assert.Equal(t, observedAt, response.LastAcquisition)
+	require.NotNil(t, response.AsOf)
+	assert.Equal(t, asOf, *response.AsOf)
+}
+
+func TestListExchangesUseCase_Execute_AsOfServiceError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExchangeService(ctrl)
+	useCase := NewListExchangesUseCase(mockService)
+
+	ctx := context.Background()
+	asOf := time.Now()
+	expectedError := errors.New("database error")
+
+	mockService.EXPECT().
+		ListExchangesAsOf(ctx, "", "", asOf).
+		Return(nil, expectedError)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.ListExchangesRequest{AsOf: &asOf})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
@@ -0,0 +1,55 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+)
+
+type syncExchangeRatesUseCase struct {
+	exchangeService    entity.ExchangeService
+	exchangeRateClient exchangerate.Client
+}
+
+func (s *syncExchangeRatesUseCase) Execute(ctx context.Context, req entity.SyncExchangeRatesRequest) (*entity.SyncExchangeRatesResponse, error) {
+	rates, err := s.exchangeRateClient.GetExchangeRates(ctx, req.SourceCurrency, req.TargetCurrencies)
+	if len(rates) == 0 && err != nil {
+		return nil, err
+	}
+
+	errs := []error{err}
+	response := &entity.SyncExchangeRatesResponse{
+		Rates: make(map[string]entity.SyncExchangeRateResponse, len(rates)),
+	}
+	for _, target := range req.TargetCurrencies {
+		rate, ok := rates[target]
+		if !ok {
+			continue
+		}
+
+		err := s.exchangeService.ReceiveExchangeRate(ctx, req.SourceCurrency, target, rate.Rate, rate.Spread)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s-%s: %w", req.SourceCurrency, target, err))
+			continue
+		}
+
+		response.Rates[target] = entity.SyncExchangeRateResponse{
+			Rate:      rate.Rate,
+			Providers: rate.Providers,
+			Spread:    rate.Spread,
+		}
+	}
+
+	return response, errors.Join(errs...)
+}
+
+// NewSyncExchangeRatesUseCase returns a use case getting all targets of a
+// source currency in a single provider call, where the provider can batch.
+func NewSyncExchangeRatesUseCase(exchangeService entity.ExchangeService, exchangeRateClient exchangerate.Client) entity.SyncExchangeRatesUseCase {
+	return &syncExchangeRatesUseCase{
+		exchangeService:    exchangeService,
+		exchangeRateClient: exchangeRateClient,
+	}
+}
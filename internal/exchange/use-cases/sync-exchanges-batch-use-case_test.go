@@ -0,0 +1,158 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	clientMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	entityMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestSyncExchangeRatesUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewSyncExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	spread := decimal.NewNullDecimal(decimal.RequireFromString("0.001"))
+
+	mockClient.EXPECT().
+		GetExchangeRates(ctx, "USD", []string{"BRL", "EUR"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{
+			"BRL": {Rate: decimal.RequireFromString("5.25"), Spread: spread},
+			"EUR": {Rate: decimal.RequireFromString("0.92")},
+		}, nil)
+
+	gomock.InOrder(
+		mockService.EXPECT().
+			ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), spread).
+			Return(nil),
+		mockService.EXPECT().
+			ReceiveExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), decimal.NullDecimal{}).
+			Return(nil),
+	)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.SyncExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL", "EUR"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Rates, 2)
+	assert.Equal(t, "5.25", result.Rates["BRL"].Rate.String())
+	assert.Equal(t, spread, result.Rates["BRL"].Spread)
+}
+
+func TestSyncExchangeRatesUseCase_Execute_PartialRates(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewSyncExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	expectedError := errors.New("USD-XYZ: unknown currency")
+
+	mockClient.EXPECT().
+		GetExchangeRates(ctx, "USD", []string{"BRL", "XYZ"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{
+			"BRL": {Rate: decimal.RequireFromString("5.25")},
+		}, expectedError)
+
+	mockService.EXPECT().
+		ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}).
+		Return(nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.SyncExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL", "XYZ"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	require.NotNil(t, result)
+	assert.Len(t, result.Rates, 1)
+	assert.Contains(t, result.Rates, "BRL")
+}
+
+func TestSyncExchangeRatesUseCase_Execute_ClientError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewSyncExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	expectedError := errors.New("API rate limit exceeded")
+
+	mockClient.EXPECT().
+		GetExchangeRates(ctx, "USD", []string{"BRL"}).
+		Return(nil, expectedError)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.SyncExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL"},
+	})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestSyncExchangeRatesUseCase_Execute_ServiceErrorKeepsGoing(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewSyncExchangeRatesUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	expectedError := errors.New("database connection failed")
+
+	mockClient.EXPECT().
+		GetExchangeRates(ctx, "USD", []string{"BRL", "EUR"}).
+		Return(map[string]*exchangerate.GetExchangeRateResponse{
+			"BRL": {Rate: decimal.RequireFromString("5.25")},
+			"EUR": {Rate: decimal.RequireFromString("0.92")},
+		}, nil)
+
+	mockService.EXPECT().
+		ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}).
+		Return(expectedError)
+	mockService.EXPECT().
+		ReceiveExchangeRate(ctx, "USD", "EUR", decimal.RequireFromString("0.92"), decimal.NullDecimal{}).
+		Return(nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, entity.SyncExchangeRatesRequest{
+		SourceCurrency:   "USD",
+		TargetCurrencies: []string{"BRL", "EUR"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.ErrorContains(t, err, "USD-BRL")
+	require.NotNil(t, result)
+	assert.Len(t, result.Rates, 1)
+	assert.Contains(t, result.Rates, "EUR")
+}
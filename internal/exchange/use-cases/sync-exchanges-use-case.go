@@ -20,13 +20,15 @@ func (s *syncExchangeRateUseCase) Execute(ctx context.Context, req entity.SyncEx
 		return nil, err
 	}
 
-	err = s.exchangeService.ReceiveExchangeRate(ctx, req.SourceCurrency, req.TargetCurrency, resp.Rate)
+	err = s.exchangeService.ReceiveExchangeRate(ctx, req.SourceCurrency, req.TargetCurrency, resp.Rate, resp.Spread)
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.SyncExchangeRateResponse{
-		Rate: resp.Rate,
+		Rate:      resp.Rate,
+		Providers: resp.Providers,
+		Spread:    resp.Spread,
 	}, nil
 }
 
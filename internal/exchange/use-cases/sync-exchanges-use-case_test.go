@@ -7,6 +7,7 @@ import (
 	clientMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
 	entityMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -33,7 +34,7 @@ func TestSyncExchangeRateUseCase_Execute_Success(t *testing.T) {
 		To:   "BRL",
 	}
 	clientResp := &exchangerate.GetExchangeRateResponse{
-		Rate: 5.25,
+		Rate: decimal.RequireFromString("5.25"),
 	}
 
 	mockClient.EXPECT().
@@ -41,7 +42,7 @@ func TestSyncExchangeRateUseCase_Execute_Success(t *testing.T) {
 		Return(clientResp, nil)
 
 	mockService.EXPECT().
-		ReceiveExchangeRate(ctx, "USD", "BRL", 5.25).
+		ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), decimal.NullDecimal{}).
 		Return(nil)
 
 	// Act
@@ -50,7 +51,46 @@ func TestSyncExchangeRateUseCase_Execute_Success(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	assert.Equal(t, 5.25, result.Rate)
+	assert.Equal(t, "5.25", result.Rate.String())
+}
+
+func TestSyncExchangeRateUseCase_Execute_StoresSpread(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := entityMocks.NewMockExchangeService(ctrl)
+	mockClient := clientMocks.NewMockClient(ctrl)
+	useCase := NewSyncExchangeRateUseCase(mockService, mockClient)
+
+	ctx := context.Background()
+	req := entity.SyncExchangeRateRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+	}
+
+	spread := decimal.NewNullDecimal(decimal.RequireFromString("0.004"))
+	clientResp := &exchangerate.GetExchangeRateResponse{
+		Rate:      decimal.RequireFromString("5.25"),
+		Providers: []string{"freecurrencyapi", "http"},
+		Spread:    spread,
+	}
+
+	mockClient.EXPECT().
+		GetExchangeRate(ctx, exchangerate.GetExchangeRateRequest{From: "USD", To: "BRL"}).
+		Return(clientResp, nil)
+
+	mockService.EXPECT().
+		ReceiveExchangeRate(ctx, "USD", "BRL", decimal.RequireFromString("5.25"), spread).
+		Return(nil)
+
+	// Act
+	result, err := useCase.Execute(ctx, req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"freecurrencyapi", "http"}, result.Providers)
+	assert.Equal(t, spread, result.Spread)
 }
 
 func TestSyncExchangeRateUseCase_Execute_ClientError(t *testing.T) {
@@ -107,7 +147,7 @@ func TestSyncExchangeRateUseCase_Execute_ServiceError(t *testing.T) {
 		To:   "BRL",
 	}
 	clientResp := &exchangerate.GetExchangeRateResponse{
-		Rate: 5.75,
+		Rate: decimal.RequireFromString("5.75"),
 	}
 	expectedError := errors.New("database connection failed")
 
@@ -116,7 +156,7 @@ func TestSyncExchangeRateUseCase_Execute_ServiceError(t *testing.T) {
 		Return(clientResp, nil)
 
 	mockService.EXPECT().
-		ReceiveExchangeRate(ctx, "EUR", "BRL", 5.75).
+		ReceiveExchangeRate(ctx, "EUR", "BRL", decimal.RequireFromString("5.75"), decimal.NullDecimal{}).
 		Return(expectedError)
 
 	// Act
@@ -133,11 +173,11 @@ func TestSyncExchangeRateUseCase_Execute_DifferentCurrencyPairs(t *testing.T) {
 		name string
 		from string
 		to   string
-		rate float64
+		rate decimal.Decimal
 	}{
-		{"USD to EUR", "USD", "EUR", 0.92},
-		{"GBP to USD", "GBP", "USD", 1.27},
-		{"JPY to BRL", "JPY", "BRL", 0.034},
+		{"USD to EUR", "USD", "EUR", decimal.RequireFromString("0.92")},
+		{"GBP to USD", "GBP", "USD", decimal.RequireFromString("1.27")},
+		{"JPY to BRL", "JPY", "BRL", decimal.RequireFromString("0.034")},
 	}
 
 	for _, tc := range testCases {
@@ -169,7 +209,7 @@ func TestSyncExchangeRateUseCase_Execute_DifferentCurrencyPairs(t *testing.T) {
 				Return(clientResp, nil)
 
 			mockService.EXPECT().
-				ReceiveExchangeRate(ctx, tc.from, tc.to, tc.rate).
+				ReceiveExchangeRate(ctx, tc.from, tc.to, tc.rate, decimal.NullDecimal{}).
 				Return(nil)
 
 			// Act
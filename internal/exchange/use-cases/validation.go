@@ -0,0 +1,34 @@
+package use_cases
+
+import (
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+)
+
+// currencyField is a currency code of a request, named after its field.
+type currencyField struct {
+	name     string
+	code     *string
+	optional bool
+}
+
+// normalizeCurrencies upper-cases every code in place and checks it against
+// ISO 4217, reporting all invalid fields at once. Empty optional codes are kept.
+func normalizeCurrencies(fields ...currencyField) error {
+	var validation entity.ValidationError
+	for _, field := range fields {
+		*field.code = entity.NormalizeCurrency(*field.code)
+		if *field.code == "" {
+			if !field.optional {
+				validation.Add(field.name, "is required")
+			}
+			continue
+		}
+
+		if !entity.IsCurrency(*field.code) {
+			validation.Add(field.name, fmt.Sprintf("%q is not an ISO 4217 currency code", *field.code))
+		}
+	}
+
+	return validation.OrNil()
+}
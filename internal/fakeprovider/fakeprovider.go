@@ -0,0 +1,402 @@
+package fakeprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/exchange-register-go/server"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inversePrecision is the number of decimal places of rates inverted from
+// injected ones.
+const inversePrecision = 15
+
+// Fault makes the next Count provider requests fail with Status, or every one
+// of them until cleared when Count is zero.
+type Fault struct {
+	Status int `json:"status"`
+	Count  int `json:"count,omitempty"`
+
+	// RetryAfter is sent in the Retry-After header when set, like "30s",
+	// rounded up to whole seconds.
+	RetryAfter string `json:"retry_after,omitempty"`
+}
+
+// LatencyRequest delays every provider request by Latency, like "250ms".
+type LatencyRequest struct {
+	Latency string `json:"latency"`
+}
+
+// State is what the admin endpoints have injected so far.
+type State struct {
+	// Rates override the source's rates, by base and target currency.
+	Rates    map[string]map[string]decimal.Decimal `json:"rates"`
+	Latency  string                                `json:"latency"`
+	Fault    *Fault                                `json:"fault,omitempty"`
+	Requests int64                                 `json:"requests"`
+}
+
+// fakeProvider serves rates from a source client on the routes of the
+// providers the app talks to, freecurrencyapi's /v1 and the http provider's
+// /convert, along with /admin routes injecting rates, latency and faults.
+type fakeProvider struct {
+	source   exchangerate.Client
+	httpPort string
+
+	mu         sync.Mutex
+	rates      map[string]map[string]decimal.Decimal
+	latency    time.Duration
+	fault      *Fault
+	retryAfter time.Duration
+	requests   int64
+}
+
+func (f *fakeProvider) GracefulListenAndShutdown(ctx context.Context) error {
+	e := f.newEcho()
+
+	go func() {
+		<-ctx.Done()
+		err := e.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to close fake provider")
+		}
+	}()
+
+	err := e.Start(":" + f.httpPort)
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// newEcho builds the echo instance with every route registered.
+func (f *fakeProvider) newEcho() *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	provider := e.Group("", f.simulate)
+	provider.GET("/v1/latest", f.latestHandler, requireAPIKey)
+	provider.GET("/v1/historical", f.historicalHandler, requireAPIKey)
+	provider.GET("/convert", f.convertHandler)
+
+	admin := e.Group("/admin")
+	admin.GET("/state", f.stateHandler)
+	admin.PUT("/rates", f.putRatesHandler)
+	admin.DELETE("/rates", f.deleteRatesHandler)
+	admin.PUT("/latency", f.putLatencyHandler)
+	admin.PUT("/fault", f.putFaultHandler)
+	admin.DELETE("/fault", f.deleteFaultHandler)
+	admin.POST("/reset", f.resetHandler)
+
+	return e
+}
+
+// simulate counts provider requests, then delays and fails them as injected.
+func (f *fakeProvider) simulate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		f.mu.Lock()
+		f.requests++
+		latency := f.latency
+		fault, retryAfter := f.fault, f.retryAfter
+		if fault != nil && fault.Count > 0 {
+			if fault.Count--; fault.Count == 0 {
+				f.fault = nil
+			}
+		}
+		f.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-c.Request().Context().Done():
+				return c.Request().Context().Err()
+			}
+		}
+
+		if fault != nil {
+			if retryAfter > 0 {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+
+			return echo.NewHTTPError(fault.Status, http.StatusText(fault.Status))
+		}
+
+		return next(c)
+	}
+}
+
+// requireAPIKey rejects requests without an apikey header or query parameter,
+// like freecurrencyapi does. Any key is accepted.
+func requireAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Header.Get("apikey") == "" && c.QueryParam("apikey") == "" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authentication credentials")
+		}
+
+		return next(c)
+	}
+}
+
+func (f *fakeProvider) latestHandler(c echo.Context) error {
+	base, targets, err := currenciesParams(c)
+	if err != nil {
+		return err
+	}
+
+	rates, err := f.ratesAt(c.Request().Context(), base, targets, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"data": rates})
+}
+
+func (f *fakeProvider) historicalHandler(c echo.Context) error {
+	date, err := time.Parse(time.DateOnly, c.QueryParam("date"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "The date must be a date in the format YYYY-MM-DD.")
+	}
+
+	base, targets, err := currenciesParams(c)
+	if err != nil {
+		return err
+	}
+
+	rates, err := f.ratesAt(c.Request().Context(), base, targets, date)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"data": map[string]any{date.Format(time.DateOnly): rates}})
+}
+
+func (f *fakeProvider) convertHandler(c echo.Context) error {
+	from, to := strings.ToUpper(c.QueryParam("from")), strings.ToUpper(c.QueryParam("to"))
+	if from == "" || to == "" {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "from and to are required")
+	}
+
+	var date time.Time
+	if c.QueryParam("date") != "" {
+		var err error
+		date, err = time.Parse(time.DateOnly, c.QueryParam("date"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "date must be a date in the format YYYY-MM-DD")
+		}
+	}
+
+	rates, err := f.ratesAt(c.Request().Context(), from, []string{to}, date)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"result": rates[to]})
+}
+
+// currenciesParams reads freecurrencyapi's base_currency, USD by default, and
+// currencies parameters.
+func currenciesParams(c echo.Context) (string, []string, error) {
+	base := strings.ToUpper(c.QueryParam("base_currency"))
+	if base == "" {
+		base = "USD"
+	}
+
+	if c.QueryParam("currencies") == "" {
+		return "", nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "The currencies field is required.")
+	}
+
+	return base, strings.Split(strings.ToUpper(c.QueryParam("currencies")), ","), nil
+}
+
+// ratesAt returns the rates from base to targets on date, the latest ones when
+// date is zero. Injected rates, or their inverse, take precedence over the source.
+func (f *fakeProvider) ratesAt(ctx context.Context, base string, targets []string, date time.Time) (map[string]json.Number, error) {
+	rates := make(map[string]json.Number, len(targets))
+	missing := make([]string, 0, len(targets))
+
+	f.mu.Lock()
+	for _, target := range targets {
+		if rate, ok := f.rates[base][target]; ok {
+			rates[target] = json.Number(rate.String())
+		} else if inverse, ok := f.rates[target][base]; ok {
+			rates[target] = json.Number(decimal.NewFromInt(1).DivRound(inverse, inversePrecision).String())
+		} else {
+			missing = append(missing, target)
+		}
+	}
+	f.mu.Unlock()
+
+	if len(missing) == 0 {
+		return rates, nil
+	}
+
+	var sourced map[string]*exchangerate.GetExchangeRateResponse
+	var err error
+	if date.IsZero() {
+		sourced, err = f.source.GetExchangeRates(ctx, base, missing)
+	} else {
+		sourced, err = f.source.GetHistoricalRates(ctx, base, missing, date)
+	}
+
+	if err != nil {
+		if errors.Is(err, exchangerate.ErrUnknownCurrency) {
+			return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "The selected currencies is invalid.").SetInternal(err)
+		}
+
+		return nil, err
+	}
+
+	for target, rate := range sourced {
+		rates[target] = json.Number(rate.Rate.String())
+	}
+
+	return rates, nil
+}
+
+func (f *fakeProvider) stateHandler(c echo.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := State{Rates: f.rates, Latency: f.latency.String(), Requests: f.requests}
+	if f.fault != nil {
+		fault := *f.fault
+		state.Fault = &fault
+	}
+
+	return c.JSON(http.StatusOK, state)
+}
+
+// putRatesHandler merges the rates in the body into the injected ones.
+func (f *fakeProvider) putRatesHandler(c echo.Context) error {
+	var rates map[string]map[string]decimal.Decimal
+	if err := c.Bind(&rates); err != nil {
+		return err
+	}
+
+	for base, targets := range rates {
+		for target, rate := range targets {
+			if !rate.IsPositive() {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("rate of %s-%s must be positive", base, target))
+			}
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for base, targets := range rates {
+		base = strings.ToUpper(base)
+		if f.rates[base] == nil {
+			f.rates[base] = map[string]decimal.Decimal{}
+		}
+
+		for target, rate := range targets {
+			f.rates[base][strings.ToUpper(target)] = rate
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (f *fakeProvider) deleteRatesHandler(c echo.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rates = map[string]map[string]decimal.Decimal{}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (f *fakeProvider) putLatencyHandler(c echo.Context) error {
+	var req LatencyRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	latency, err := time.ParseDuration(req.Latency)
+	if err != nil || latency < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "latency must be a non-negative duration, like 250ms")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.latency = latency
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (f *fakeProvider) putFaultHandler(c echo.Context) error {
+	var fault Fault
+	if err := c.Bind(&fault); err != nil {
+		return err
+	}
+
+	if fault.Status != http.StatusTooManyRequests && (fault.Status < 500 || fault.Status > 599) {
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be 429 or a 5xx")
+	}
+
+	if fault.Count < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "count must not be negative")
+	}
+
+	var retryAfter time.Duration
+	if fault.RetryAfter != "" {
+		var err error
+		retryAfter, err = time.ParseDuration(fault.RetryAfter)
+		if err != nil || retryAfter < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "retry_after must be a non-negative duration, like 30s")
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fault, f.retryAfter = &fault, retryAfter
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (f *fakeProvider) deleteFaultHandler(c echo.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fault, f.retryAfter = nil, 0
+	return c.NoContent(http.StatusNoContent)
+}
+
+// resetHandler clears everything injected and the request count.
+func (f *fakeProvider) resetHandler(c echo.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rates = map[string]map[string]decimal.Decimal{}
+	f.latency, f.fault, f.retryAfter, f.requests = 0, nil, 0, 0
+	return c.NoContent(http.StatusNoContent)
+}
+
+// NewFakeProvider returns a server faking the exchange rate providers on
+// httpPort, serving the rates of source unless others are injected.
+func NewFakeProvider(source exchangerate.Client, httpPort string) server.Server {
+	return &fakeProvider{
+		source:   source,
+		httpPort: httpPort,
+		rates:    map[string]map[string]decimal.Decimal{},
+	}
+}
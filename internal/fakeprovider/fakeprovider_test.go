@@ -0,0 +1,245 @@
+package fakeprovider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	"github.com/jorgejr568/freecurrencyapi-go/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestProvider serves a fake provider whose source rates never move from
+// USD-BRL 5 and USD-EUR 0.92.
+func newTestProvider(t *testing.T) (*httptest.Server, exchangerate.Client, exchangerate.Client) {
+	source := exchangerate.NewSimulatedClient(exchangerate.RandomWalk{
+		Anchor: "USD",
+		Rates: map[string]decimal.Decimal{
+			"BRL": decimal.RequireFromString("5"),
+			"EUR": decimal.RequireFromString("0.92"),
+		},
+		Step: time.Hour,
+	})
+
+	server := httptest.NewServer(NewFakeProvider(source, "0").(*fakeProvider).newEcho())
+	t.Cleanup(server.Close)
+
+	httpClient := &http.Client{Transport: exchangerate.NewStatusTransport(http.DefaultTransport)}
+	freeCurrencyAPI := exchangerate.NewFreeCurrencyApiClient(freecurrencyapi.NewClient("key", freecurrencyapi.Options().WithHTTPClient(httpClient).WithBaseURL(server.URL+"/v1/")))
+
+	return server, freeCurrencyAPI, exchangerate.NewHTTPClient(http.DefaultClient, server.URL)
+}
+
+func adminRequest(t *testing.T, server *httptest.Server, method, path, body string) {
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestFakeProvider_Latest_InjectedRatesOverrideSource(t *testing.T) {
+	// Arrange
+	server, freeCurrencyAPI, _ := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/rates", `{"usd": {"brl": "5.1234567891"}}`)
+
+	// Act
+	rates, err := freeCurrencyAPI.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "5.1234567891", rates["BRL"].Rate.String())
+	assert.Equal(t, "0.92", rates["EUR"].Rate.String())
+}
+
+func TestFakeProvider_Historical(t *testing.T) {
+	// Arrange
+	_, freeCurrencyAPI, _ := newTestProvider(t)
+
+	// Act
+	rates, err := freeCurrencyAPI.GetHistoricalRates(context.Background(), "EUR", []string{"USD"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "1.0869565217", rates["USD"].Rate.String())
+}
+
+func TestFakeProvider_Convert_InvertsInjectedRates(t *testing.T) {
+	// Arrange
+	server, _, httpProvider := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/rates", `{"USD": {"BRL": 4}}`)
+
+	// Act
+	latest, err := httpProvider.GetExchangeRate(context.Background(), exchangerate.GetExchangeRateRequest{From: "BRL", To: "USD"})
+	require.NoError(t, err)
+	historical, err := httpProvider.GetHistoricalRates(context.Background(), "BRL", []string{"USD"}, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "0.25", latest.Rate.String())
+	assert.Equal(t, "0.25", historical["USD"].Rate.String())
+}
+
+func TestFakeProvider_Fault_FailsCountRequests(t *testing.T) {
+	// Arrange
+	server, freeCurrencyAPI, _ := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/fault", `{"status": 429, "count": 1, "retry_after": "10s"}`)
+
+	// Act
+	_, faultErr := freeCurrencyAPI.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+	rates, err := freeCurrencyAPI.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+
+	// Assert
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, faultErr, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, 10*time.Second, statusErr.RetryAfter)
+
+	require.NoError(t, err)
+	assert.Equal(t, "5", rates["BRL"].Rate.String())
+}
+
+func TestFakeProvider_Fault_RoundsRetryAfterUp(t *testing.T) {
+	// Arrange
+	server, freeCurrencyAPI, _ := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/fault", `{"status": 503, "retry_after": "1500ms"}`)
+
+	// Act
+	_, err := freeCurrencyAPI.GetExchangeRates(context.Background(), "USD", []string{"BRL"})
+
+	// Assert
+	var statusErr *exchangerate.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 2*time.Second, statusErr.RetryAfter)
+}
+
+func TestFakeProvider_Fault_UntilCleared(t *testing.T) {
+	// Arrange
+	server, _, httpProvider := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/fault", `{"status": 503}`)
+	request := exchangerate.GetExchangeRateRequest{From: "USD", To: "BRL"}
+
+	// Act
+	_, firstErr := httpProvider.GetExchangeRate(context.Background(), request)
+	_, secondErr := httpProvider.GetExchangeRate(context.Background(), request)
+	adminRequest(t, server, http.MethodDelete, "/admin/fault", "")
+	_, err := httpProvider.GetExchangeRate(context.Background(), request)
+
+	// Assert
+	assert.True(t, exchangerate.IsRetryable(firstErr))
+	assert.True(t, exchangerate.IsRetryable(secondErr))
+	assert.NoError(t, err)
+}
+
+func TestFakeProvider_Latency(t *testing.T) {
+	// Arrange
+	server, _, httpProvider := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/latency", `{"latency": "100ms"}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := httpProvider.GetExchangeRate(ctx, exchangerate.GetExchangeRateRequest{From: "USD", To: "BRL"})
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFakeProvider_Latest_Errors(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		query  string
+		status int
+	}{
+		"missing api key":  {query: "currencies=BRL", status: http.StatusUnauthorized},
+		"unknown currency": {header: "key", query: "currencies=XYZ", status: http.StatusUnprocessableEntity},
+		"no currencies":    {header: "key", status: http.StatusUnprocessableEntity},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			server, _, _ := newTestProvider(t)
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/latest?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("apikey", tt.header)
+
+			// Act
+			res, err := http.DefaultClient.Do(req)
+
+			// Assert
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
+
+func TestFakeProvider_Admin_RejectsInvalidInjections(t *testing.T) {
+	tests := map[string]struct {
+		path string
+		body string
+	}{
+		"client error fault": {path: "/admin/fault", body: `{"status": 404}`},
+		"negative count":     {path: "/admin/fault", body: `{"status": 500, "count": -1}`},
+		"invalid latency":    {path: "/admin/latency", body: `{"latency": "soon"}`},
+		"zero rate":          {path: "/admin/rates", body: `{"USD": {"BRL": 0}}`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			server, _, _ := newTestProvider(t)
+			req, err := http.NewRequest(http.MethodPut, server.URL+tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			res, err := http.DefaultClient.Do(req)
+
+			// Assert
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+}
+
+func TestFakeProvider_State_CountsRequestsUntilReset(t *testing.T) {
+	// Arrange
+	server, _, httpProvider := newTestProvider(t)
+	adminRequest(t, server, http.MethodPut, "/admin/fault", `{"status": 500, "count": 5}`)
+	_, _ = httpProvider.GetExchangeRates(context.Background(), "USD", []string{"BRL", "EUR"})
+
+	state := func() State {
+		res, err := http.Get(server.URL + "/admin/state")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var state State
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&state))
+		return state
+	}
+
+	// Act
+	before := state()
+	adminRequest(t, server, http.MethodPost, "/admin/reset", "")
+	after := state()
+
+	// Assert
+	assert.Equal(t, int64(2), before.Requests)
+	require.NotNil(t, before.Fault)
+	assert.Equal(t, 3, before.Fault.Count)
+	assert.Equal(t, State{Rates: map[string]map[string]decimal.Decimal{}, Latency: "0s"}, after)
+}
@@ -9,10 +9,22 @@ import (
 	"fmt"
 	"github.com/vingarcia/ksql"
 	"github.com/vingarcia/ksql/adapters/kpgx"
+	"io"
+	"net/url"
 )
 
 var (
-	ErrNotFound = fmt.Errorf("row not found: %w", sql.ErrNoRows)
+	ErrNotFound            = fmt.Errorf("row not found: %w", sql.ErrNoRows)
+	ErrUnsupportedDatabase = errors.New("unsupported database url scheme")
+)
+
+// Dialect identifies the SQL dialect spoken by a DB, for the few statements
+// (mostly DDL) that can't be written portably.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
 )
 
 type DB interface {
@@ -25,21 +37,32 @@ type DB interface {
 	// QueryOne executes a query that returns one row, typically a SELECT.
 	QueryOne(ctx context.Context, target interface{}, query string, args ...interface{}) error
 
+	// Transaction runs fn inside a database transaction. The transaction is
+	// committed when fn returns nil and rolled back otherwise. Calling
+	// Transaction on the DB received by fn reuses the running transaction.
+	Transaction(ctx context.Context, fn func(tx DB) error) error
+
+	// Dialect returns the SQL dialect of the database.
+	Dialect() Dialect
+
 	// Close closes the database, releasing any open resources.
 	Close() error
 }
 
-type ksqlPgDB struct{ db *ksql.DB }
+type ksqlDB struct {
+	db      ksql.Provider
+	dialect Dialect
+}
 
-func (k ksqlPgDB) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+func (k ksqlDB) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	return k.db.Exec(ctx, query, args...)
 }
 
-func (k ksqlPgDB) Query(ctx context.Context, target interface{}, query string, args ...interface{}) error {
+func (k ksqlDB) Query(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 	return k.db.Query(ctx, target, query, args...)
 }
 
-func (k ksqlPgDB) QueryOne(ctx context.Context, target interface{}, query string, args ...interface{}) error {
+func (k ksqlDB) QueryOne(ctx context.Context, target interface{}, query string, args ...interface{}) error {
 	err := k.db.QueryOne(ctx, target, query, args...)
 	if err != nil {
 		if errors.Is(err, ksql.ErrRecordNotFound) {
@@ -52,8 +75,23 @@ func (k ksqlPgDB) QueryOne(ctx context.Context, target interface{}, query string
 	return nil
 }
 
-func (k ksqlPgDB) Close() error {
-	return k.db.Close()
+func (k ksqlDB) Transaction(ctx context.Context, fn func(tx DB) error) error {
+	return k.db.Transaction(ctx, func(provider ksql.Provider) error {
+		return fn(&ksqlDB{db: provider, dialect: k.dialect})
+	})
+}
+
+func (k ksqlDB) Dialect() Dialect {
+	return k.dialect
+}
+
+func (k ksqlDB) Close() error {
+	closer, ok := k.db.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	return closer.Close()
 }
 
 func NewKsqlPgDB(ctx context.Context, connection string) (DB, error) {
@@ -65,7 +103,30 @@ func NewKsqlPgDB(ctx context.Context, connection string) (DB, error) {
 		return nil, err
 	}
 
-	return &ksqlPgDB{
-		db: &db,
+	return &ksqlDB{
+		db:      db,
+		dialect: DialectPostgres,
 	}, nil
 }
+
+// NewDB connects to the database described by connection, picking the driver
+// from its scheme: postgres:// (or postgresql://) and sqlite://.
+func NewDB(ctx context.Context, connection string) (DB, error) {
+	if connection == "" {
+		return nil, fmt.Errorf("%w: empty database url", ErrUnsupportedDatabase)
+	}
+
+	u, err := url.Parse(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "postgres", "postgresql":
+		return NewKsqlPgDB(ctx, connection)
+	case "sqlite":
+		return NewKsqlSQLiteDB(ctx, sqlitePath(u))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDatabase, u.Scheme)
+	}
+}
@@ -0,0 +1,133 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+type testRow struct {
+	ID   int64  `ksql:"id"`
+	Name string `ksql:"name"`
+}
+
+func setupSQLiteDB(t *testing.T) DB {
+	ctx := context.Background()
+	db, err := NewDB(ctx, "sqlite://"+filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(ctx, `CREATE TABLE rows (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestNewDB_SQLite(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	ctx := context.Background()
+
+	// Act
+	_, err := db.Exec(ctx, `INSERT INTO rows (name) VALUES ($1)`, "first")
+	require.NoError(t, err)
+
+	var row testRow
+	err = db.QueryOne(ctx, &row, `SELECT id, name FROM rows WHERE name = $1`, "first")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, DialectSQLite, db.Dialect())
+	assert.Equal(t, "first", row.Name)
+}
+
+func TestNewDB_SQLiteInMemory(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db, err := NewDB(ctx, "sqlite://:memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Act - The in-memory database must survive across statements
+	_, err = db.Exec(ctx, `CREATE TABLE rows (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	var rows []testRow
+	err = db.Query(ctx, &rows, `SELECT id, name FROM rows`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestNewDB_UnsupportedScheme(t *testing.T) {
+	// Act
+	db, err := NewDB(context.Background(), "mysql://localhost:3306/exchange")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnsupportedDatabase)
+	assert.Nil(t, db)
+}
+
+func TestKsqlDB_QueryOne_NotFound(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+
+	// Act
+	var row testRow
+	err := db.QueryOne(context.Background(), &row, `SELECT id, name FROM rows WHERE name = $1`, "missing")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestKsqlDB_Transaction_RollsBackOnError(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	ctx := context.Background()
+	expectedError := errors.New("second write failed")
+
+	// Act
+	err := db.Transaction(ctx, func(tx DB) error {
+		_, err := tx.Exec(ctx, `INSERT INTO rows (name) VALUES ($1)`, "first")
+		require.NoError(t, err)
+
+		return expectedError
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+
+	var rows []testRow
+	err = db.Query(ctx, &rows, `SELECT id, name FROM rows`)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestKsqlDB_Transaction_Commits(t *testing.T) {
+	// Arrange
+	db := setupSQLiteDB(t)
+	ctx := context.Background()
+
+	// Act
+	err := db.Transaction(ctx, func(tx DB) error {
+		_, err := tx.Exec(ctx, `INSERT INTO rows (name) VALUES ($1)`, "first")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO rows (name) VALUES ($1)`, "second")
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+
+	var rows []testRow
+	err = db.Query(ctx, &rows, `SELECT id, name FROM rows`)
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
@@ -14,6 +14,7 @@ import (
 	sql "database/sql"
 	reflect "reflect"
 
+	infra "github.com/jorgejr568/exchange-register-go/internal/infra"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,6 +56,20 @@ func (mr *MockDBMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
 }
 
+// Dialect mocks base method.
+func (m *MockDB) Dialect() infra.Dialect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dialect")
+	ret0, _ := ret[0].(infra.Dialect)
+	return ret0
+}
+
+// Dialect indicates an expected call of Dialect.
+func (mr *MockDBMockRecorder) Dialect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dialect", reflect.TypeOf((*MockDB)(nil).Dialect))
+}
+
 // Exec mocks base method.
 func (m *MockDB) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -112,3 +127,17 @@ func (mr *MockDBMockRecorder) QueryOne(ctx, target, query any, args ...any) *gom
 	varargs := append([]any{ctx, target, query}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryOne", reflect.TypeOf((*MockDB)(nil).QueryOne), varargs...)
 }
+
+// Transaction mocks base method.
+func (m *MockDB) Transaction(ctx context.Context, fn func(infra.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockDBMockRecorder) Transaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockDB)(nil).Transaction), ctx, fn)
+}
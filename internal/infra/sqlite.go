@@ -0,0 +1,116 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"github.com/vingarcia/ksql"
+	_ "modernc.org/sqlite"
+	"net/url"
+	"strings"
+)
+
+// sqliteParams are always added to the SQLite DSN: foreign keys are off by
+// default in SQLite, immediate transactions take the write lock upfront so
+// concurrent writers wait for each other instead of failing to upgrade their
+// lock, and the time format keeps timestamps comparable as text.
+var sqliteParams = []string{
+	"_pragma=foreign_keys(1)",
+	"_pragma=busy_timeout(5000)",
+	"_pragma=journal_mode(WAL)",
+	"_txlock=immediate",
+	"_time_format=sqlite",
+}
+
+// sqlAdapter adapts a database/sql connection to the ksql.DBAdapter interface.
+type sqlAdapter struct{ db *sql.DB }
+
+func (s sqlAdapter) ExecContext(ctx context.Context, query string, args ...interface{}) (ksql.Result, error) {
+	return s.db.ExecContext(ctx, query, args...)
+}
+
+func (s sqlAdapter) QueryContext(ctx context.Context, query string, args ...interface{}) (ksql.Rows, error) {
+	return s.db.QueryContext(ctx, query, args...)
+}
+
+func (s sqlAdapter) BeginTx(ctx context.Context) (ksql.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlTx{tx: tx}, nil
+}
+
+func (s sqlAdapter) Close() error {
+	return s.db.Close()
+}
+
+type sqlTx struct{ tx *sql.Tx }
+
+func (s sqlTx) ExecContext(ctx context.Context, query string, args ...interface{}) (ksql.Result, error) {
+	return s.tx.ExecContext(ctx, query, args...)
+}
+
+func (s sqlTx) QueryContext(ctx context.Context, query string, args ...interface{}) (ksql.Rows, error) {
+	return s.tx.QueryContext(ctx, query, args...)
+}
+
+func (s sqlTx) Rollback(ctx context.Context) error {
+	return s.tx.Rollback()
+}
+
+func (s sqlTx) Commit(ctx context.Context) error {
+	return s.tx.Commit()
+}
+
+// NewKsqlSQLiteDB opens the SQLite database stored at path, creating it if needed.
+// Use ":memory:" for a database that only lives as long as the connection.
+func NewKsqlSQLiteDB(ctx context.Context, path string) (DB, error) {
+	dsn := "file:" + path
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	dsn += separator + strings.Join(sqliteParams, "&")
+
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite has a single writer, a single connection avoids "database is locked"
+	// errors and keeps ":memory:" databases alive across queries.
+	sqlDB.SetMaxOpenConns(1)
+	err = sqlDB.PingContext(ctx)
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	db, err := ksql.NewWithAdapter(sqlAdapter{db: sqlDB}, "sqlite3")
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return &ksqlDB{
+		db:      db,
+		dialect: DialectSQLite,
+	}, nil
+}
+
+// sqlitePath extracts the database path from a sqlite:// url, supporting
+// relative (sqlite://exchange.db), absolute (sqlite:///var/lib/exchange.db)
+// and in-memory (sqlite://:memory:) databases.
+func sqlitePath(u *url.URL) string {
+	path := u.Host + u.Path
+	if u.Opaque != "" {
+		path = u.Opaque
+	}
+
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return path
+}
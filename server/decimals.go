@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// decimalsParam is the Accept parameter choosing how decimals are written, as
+// in "application/json; decimals=number" or "application/json; decimals=string".
+const decimalsParam = "decimals"
+
+// decimalSerializer writes JSON like echo's default serializer, except that the
+// fields tagged format:"decimal" become JSON numbers for clients that predate
+// decimal rates. Clients ask for numbers through the Accept header, and get them
+// by default when numbers is set. Decimals are otherwise strings, which keep
+// their precision.
+type decimalSerializer struct {
+	echo.DefaultJSONSerializer
+	numbers bool
+}
+
+func (d decimalSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	fields := decimalFieldsOf(reflect.TypeOf(i))
+	if fields == nil || !d.wantsNumbers(c.Request()) {
+		return d.DefaultJSONSerializer.Serialize(c, i, indent)
+	}
+
+	body, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	var unquoted bytes.Buffer
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := fields.unquote(decoder, &unquoted); err != nil {
+		return err
+	}
+
+	body = unquoted.Bytes()
+	if indent != "" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", indent); err != nil {
+			return err
+		}
+		body = indented.Bytes()
+	}
+
+	_, err = c.Response().Write(append(body, '\n'))
+	return err
+}
+
+// wantsNumbers reports whether decimals should be written as numbers, as the
+// first Accept media range saying so asks, or as configured otherwise.
+func (d decimalSerializer) wantsNumbers(req *http.Request) bool {
+	for _, accept := range strings.Split(strings.Join(req.Header.Values(echo.HeaderAccept), ","), ",") {
+		_, params, err := mime.ParseMediaType(accept)
+		if err != nil {
+			continue
+		}
+
+		switch params[decimalsParam] {
+		case "number":
+			return true
+		case "string":
+			return false
+		}
+	}
+
+	return d.numbers
+}
+
+// decimalFields mirrors the JSON shape of a type down to its fields tagged
+// format:"decimal", so that only those are unquoted and not other fields that
+// share their name. A nil *decimalFields has no decimals.
+type decimalFields struct {
+	decimal bool
+
+	// fields are a struct's by JSON name, and elem the elements of a slice or
+	// the values of a map.
+	fields map[string]*decimalFields
+	elem   *decimalFields
+}
+
+// decimalFieldsCache holds the *decimalFields of every type serialized so far.
+var decimalFieldsCache sync.Map
+
+// decimalFieldsOf returns the decimals of t, or nil when it has none.
+func decimalFieldsOf(t reflect.Type) *decimalFields {
+	if t == nil {
+		return nil
+	}
+
+	if cached, ok := decimalFieldsCache.Load(t); ok {
+		return cached.(*decimalFields)
+	}
+
+	fields := newDecimalFields(t, make(map[reflect.Type]bool))
+	decimalFieldsCache.Store(t, fields)
+	return fields
+}
+
+// newDecimalFields walks t, skipping types already being walked so recursive
+// types end.
+func newDecimalFields(t reflect.Type, walking map[reflect.Type]bool) *decimalFields {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if walking[t] {
+		return nil
+	}
+	walking[t] = true
+	defer delete(walking, t)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if elem := newDecimalFields(t.Elem(), walking); elem != nil {
+			return &decimalFields{elem: elem}
+		}
+	case reflect.Struct:
+		fields := make(map[string]*decimalFields)
+		addStructFields(fields, t, walking)
+		if len(fields) > 0 {
+			return &decimalFields{fields: fields}
+		}
+	}
+
+	return nil
+}
+
+// addStructFields adds the decimals of t's fields to fields, including those
+// promoted from embedded structs.
+func addStructFields(fields map[string]*decimalFields, t reflect.Type, walking map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			if !walking[embedded] {
+				walking[embedded] = true
+				addStructFields(fields, embedded, walking)
+				delete(walking, embedded)
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if field.Tag.Get("format") == "decimal" {
+			fields[name] = &decimalFields{decimal: true}
+		} else if nested := newDecimalFields(field.Type, walking); nested != nil {
+			fields[name] = nested
+		}
+	}
+}
+
+// unquote copies the next JSON value from decoder to out, writing the decimals
+// among it as numbers.
+func (d *decimalFields) unquote(decoder *json.Decoder, out *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		if s, ok := token.(string); ok && d != nil && d.decimal && isJSONNumber(s) {
+			out.WriteString(s)
+			return nil
+		}
+
+		return writeJSON(out, token)
+	}
+
+	out.WriteRune(rune(delim))
+	for i := 0; decoder.More(); i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+
+		child := d.element()
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			if err := writeJSON(out, key); err != nil {
+				return err
+			}
+			out.WriteByte(':')
+			child = d.field(key.(string))
+		}
+
+		if err := child.unquote(decoder, out); err != nil {
+			return err
+		}
+	}
+
+	end, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	out.WriteRune(rune(end.(json.Delim)))
+	return nil
+}
+
+// field returns the decimals of an object's member, which are a struct's field
+// or a map's value.
+func (d *decimalFields) field(name string) *decimalFields {
+	if d == nil {
+		return nil
+	}
+
+	if d.fields != nil {
+		return d.fields[name]
+	}
+
+	return d.elem
+}
+
+func (d *decimalFields) element() *decimalFields {
+	if d == nil {
+		return nil
+	}
+
+	return d.elem
+}
+
+func writeJSON(out *bytes.Buffer, v interface{}) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	out.Write(encoded)
+	return nil
+}
+
+// isJSONNumber reports whether s can be written as a JSON number as is.
+func isJSONNumber(s string) bool {
+	return s != "" && (s[0] == '-' || s[0] >= '0' && s[0] <= '9') && json.Valid([]byte(s))
+}
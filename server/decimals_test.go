@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decimalsTestQuote struct {
+	Rate decimal.Decimal `json:"rate" format:"decimal"`
+}
+
+type decimalsTestResponse struct {
+	Rate   decimal.NullDecimal          `json:"rate" format:"decimal"`
+	Label  decimalsTestLabel            `json:"label"`
+	Quotes map[string]decimalsTestQuote `json:"quotes"`
+}
+
+// decimalsTestLabel shares the name of a decimal field without being one.
+type decimalsTestLabel struct {
+	Rate string `json:"rate"`
+}
+
+func TestDecimalSerializer_UnquotesOnlyDecimalFields(t *testing.T) {
+	// Arrange
+	serializer := decimalSerializer{numbers: true}
+	response := decimalsTestResponse{
+		Rate:   decimal.NewNullDecimal(decimal.RequireFromString("5.5")),
+		Label:  decimalsTestLabel{Rate: "42"},
+		Quotes: map[string]decimalsTestQuote{"BRL": {Rate: decimal.RequireFromString("-0.125")}},
+	}
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	// Act
+	err := serializer.Serialize(c, &response, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rate":5.5,"label":{"rate":"42"},"quotes":{"BRL":{"rate":-0.125}}}`, rec.Body.String())
+}
+
+func TestDecimalSerializer_KeepsNullDecimals(t *testing.T) {
+	// Arrange
+	serializer := decimalSerializer{numbers: true}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	// Act
+	err := serializer.Serialize(c, []decimalsTestResponse{{}}, "  ")
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"rate":null,"label":{"rate":""},"quotes":null}]`, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "\n  {")
+}
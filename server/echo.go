@@ -3,30 +3,47 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 )
 
-type echoServer struct {
-	listExchangesUseCase entity.ListExchangesUseCase
-	httpPort             string
+// defaultHistoryWindow is how far back history goes when from is omitted.
+const defaultHistoryWindow = 30 * 24 * time.Hour
+
+// UseCases are the use cases served over HTTP.
+type UseCases struct {
+	ListExchanges   entity.ListExchangesUseCase
+	GetExchange     entity.GetExchangeUseCase
+	ExchangeHistory entity.ExchangeHistoryUseCase
+	Convert         entity.ConvertUseCase
+
+	// ProviderStatus is reported on /status when set, which it is only when the
+	// sync worker runs in the same process.
+	ProviderStatus exchangerate.ProviderStatusReporter
+
+	// SyncSchedule is reported on /status along with ProviderStatus.
+	SyncSchedule scheduler.ProjectionReporter
 }
 
-func (s *echoServer) GracefulListenAndShutdown(ctx context.Context) error {
-	e := echo.New()
-	e.HideBanner = true
+type echoServer struct {
+	useCases UseCases
+	httpPort string
 
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
+	// decimalNumbers makes decimals JSON numbers for clients that don't ask otherwise.
+	decimalNumbers bool
+}
 
-	e.GET("/status", s.statusHandler)
-	e.GET("/exchanges", s.exchangesHandler)
-	e.GET("/openapi.json", s.openapiHandler)
-	e.GET("/docs", s.docsHandler)
+func (s *echoServer) GracefulListenAndShutdown(ctx context.Context) error {
+	e := s.newEcho()
 
 	go func() {
 		<-ctx.Done()
@@ -47,37 +64,190 @@ func (s *echoServer) GracefulListenAndShutdown(ctx context.Context) error {
 	return nil
 }
 
-func NewEchoServer(listExchangesUseCase entity.ListExchangesUseCase, httpPort string) Server {
+// newEcho builds the echo instance with every middleware and route registered.
+func (s *echoServer) newEcho() *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HTTPErrorHandler = httpErrorHandler
+	e.JSONSerializer = decimalSerializer{numbers: s.decimalNumbers}
+
+	e.Use(middleware.RequestID())
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	e.GET("/status", s.statusHandler)
+	e.GET("/exchanges", s.exchangesHandler)
+	e.GET("/exchanges/:source/:target", s.exchangeHandler)
+	e.GET("/exchanges/:source/:target/history", s.exchangeHistoryHandler)
+	e.GET("/convert", s.convertHandler)
+	e.GET("/openapi.json", s.openapiHandler)
+	e.GET("/docs", s.docsHandler)
+
+	return e
+}
+
+func NewEchoServer(useCases UseCases, httpPort string, decimalNumbers bool) Server {
 	return &echoServer{
-		listExchangesUseCase: listExchangesUseCase,
-		httpPort:             httpPort,
+		useCases:       useCases,
+		httpPort:       httpPort,
+		decimalNumbers: decimalNumbers,
 	}
 }
 
 // Helper method to extract handler functions from the server
 func (s *echoServer) statusHandler(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "ok",
-	})
+	res := StatusResponse{Status: "ok"}
+	if s.useCases.ProviderStatus != nil {
+		res.Providers = s.useCases.ProviderStatus.ProviderStatuses()
+	}
+	if s.useCases.SyncSchedule != nil {
+		projection := s.useCases.SyncSchedule.Projection()
+		res.Sync = &projection
+	}
+
+	return c.JSON(http.StatusOK, res)
 }
 
 func (s *echoServer) exchangesHandler(c echo.Context) error {
 	ctx := c.Request().Context()
+	asOf, err := asOfParam(c)
+	if err != nil {
+		return err
+	}
+
 	req := entity.ListExchangesRequest{
 		SourceCurrency: c.QueryParam("source"),
 		TargetCurrency: c.QueryParam("target"),
+		AsOf:           asOf,
 	}
 
-	res, err := s.listExchangesUseCase.Execute(ctx, req)
+	res, err := s.useCases.ListExchanges.Execute(ctx, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to list exchanges",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, res)
 }
 
+func (s *echoServer) exchangeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	asOf, err := asOfParam(c)
+	if err != nil {
+		return err
+	}
+
+	req := entity.GetExchangeRequest{
+		SourceCurrency: c.Param("source"),
+		TargetCurrency: c.Param("target"),
+		AsOf:           asOf,
+	}
+
+	res, err := s.useCases.GetExchange.Execute(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// asOfParam reads the optional as_of query parameter.
+func asOfParam(c echo.Context) (*time.Time, error) {
+	if c.QueryParam("as_of") == "" {
+		return nil, nil
+	}
+
+	asOf, err := parseTimeParam("as_of", c.QueryParam("as_of"), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &asOf, nil
+}
+
+func (s *echoServer) exchangeHistoryHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	req, err := exchangeHistoryRequest(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.useCases.ExchangeHistory.Execute(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// exchangeHistoryRequest reads the history query parameters. The range defaults
+// to the last 30 days and the interval to one day.
+func exchangeHistoryRequest(c echo.Context) (entity.ExchangeHistoryRequest, error) {
+	interval, err := entity.ParseHistoryInterval(c.QueryParam("interval"))
+	if err != nil {
+		return entity.ExchangeHistoryRequest{}, invalidField("interval", err.Error())
+	}
+
+	aggregation, err := entity.ParseHistoryAggregation(c.QueryParam("aggregation"))
+	if err != nil {
+		return entity.ExchangeHistoryRequest{}, invalidField("aggregation", err.Error())
+	}
+
+	to, err := parseTimeParam("to", c.QueryParam("to"), time.Now().UTC())
+	if err != nil {
+		return entity.ExchangeHistoryRequest{}, err
+	}
+
+	from, err := parseTimeParam("from", c.QueryParam("from"), to.Add(-defaultHistoryWindow))
+	if err != nil {
+		return entity.ExchangeHistoryRequest{}, err
+	}
+
+	if from.After(to) {
+		return entity.ExchangeHistoryRequest{}, invalidField("from", "must not be after to")
+	}
+
+	return entity.ExchangeHistoryRequest{
+		SourceCurrency: c.Param("source"),
+		TargetCurrency: c.Param("target"),
+		From:           from,
+		To:             to,
+		Interval:       interval,
+		Aggregation:    aggregation,
+	}, nil
+}
+
+func (s *echoServer) convertHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	req, err := convertRequest(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.useCases.Convert.Execute(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func convertRequest(c echo.Context) (entity.ConvertRequest, error) {
+	if c.QueryParam("amount") == "" {
+		return entity.ConvertRequest{}, invalidField("amount", "is required")
+	}
+
+	amount, err := decimal.NewFromString(c.QueryParam("amount"))
+	if err != nil {
+		return entity.ConvertRequest{}, invalidField("amount", fmt.Sprintf("%q is not a decimal number", c.QueryParam("amount")))
+	}
+
+	return entity.ConvertRequest{
+		From:   c.QueryParam("from"),
+		To:     c.QueryParam("to"),
+		Amount: amount,
+	}, nil
+}
+
 func (s *echoServer) docsHandler(c echo.Context) error {
 	return c.File("static/docs/index.html")
 }
@@ -86,9 +256,7 @@ func (s *echoServer) openapiHandler(c echo.Context) error {
 	serverURL := getServerURL(c)
 	spec, err := GenerateOpenAPISpec(serverURL)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to generate openapi spec",
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, spec)
 }
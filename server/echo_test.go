@@ -4,14 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
+	clientMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate/mocks"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
+	schedulerMocks "github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler/mocks"
 	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -24,7 +30,7 @@ func TestStatusEndpoint_Success(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	req := httptest.NewRequest(http.MethodGet, "/status", nil)
 	rec := httptest.NewRecorder()
@@ -43,6 +49,87 @@ func TestStatusEndpoint_Success(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 }
 
+func TestStatusEndpoint_ReportsProviders(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReporter := clientMocks.NewMockProviderStatusReporter(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ProviderStatus: mockReporter}, "8080", false).(*echoServer)
+
+	since := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockReporter.EXPECT().
+		ProviderStatuses().
+		Return([]exchangerate.BreakerStatus{
+			{Provider: "freecurrencyapi", State: exchangerate.BreakerOpen, Since: since, ConsecutiveFailures: 5},
+			{Provider: "http", State: exchangerate.BreakerClosed, Since: since},
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := server.statusHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response StatusResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response.Status)
+	require.Len(t, response.Providers, 2)
+	assert.Equal(t, exchangerate.BreakerOpen, response.Providers[0].State)
+	assert.Equal(t, 5, response.Providers[0].ConsecutiveFailures)
+	assert.Equal(t, "http", response.Providers[1].Provider)
+}
+
+func TestStatusEndpoint_ReportsSyncProjection(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReporter := schedulerMocks.NewMockProjectionReporter(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{SyncSchedule: mockReporter}, "8080", false).(*echoServer)
+
+	nextRun := time.Date(2024, 3, 16, 1, 55, 12, 0, time.UTC)
+	mockReporter.EXPECT().
+		Projection().
+		Return(scheduler.Projection{
+			Mode:         scheduler.ModeBudget,
+			Budget:       1000,
+			CallsUsed:    200,
+			CallsLeft:    800,
+			CallsPerSync: 4,
+			Interval:     "1h55m12s",
+			NextRun:      nextRun,
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := server.statusHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"calls_remaining":800`)
+
+	var response StatusResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.NotNil(t, response.Sync)
+	assert.Equal(t, int64(200), response.Sync.CallsUsed)
+	assert.Equal(t, nextRun, response.Sync.NextRun)
+	assert.Empty(t, response.Providers)
+}
+
 func TestExchangesEndpoint_Success_WithFilters(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -50,7 +137,7 @@ func TestExchangesEndpoint_Success_WithFilters(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	now := time.Now()
 	expectedResponse := entity.ListExchangesResponse{
@@ -58,7 +145,7 @@ func TestExchangesEndpoint_Success_WithFilters(t *testing.T) {
 			ID:              1,
 			SourceCurrency:  "USD",
 			TargetCurrency:  "BRL",
-			Rate:            5.25,
+			Rate:            decimal.RequireFromString("5.25"),
 			LastAcquisition: now,
 		},
 	}
@@ -91,7 +178,7 @@ func TestExchangesEndpoint_Success_WithFilters(t *testing.T) {
 	assert.Len(t, response, 1)
 	assert.Equal(t, "USD", response[0].SourceCurrency)
 	assert.Equal(t, "BRL", response[0].TargetCurrency)
-	assert.Equal(t, 5.25, response[0].Rate)
+	assert.Equal(t, "5.25", response[0].Rate.String())
 }
 
 func TestExchangesEndpoint_Success_WithoutFilters(t *testing.T) {
@@ -101,7 +188,7 @@ func TestExchangesEndpoint_Success_WithoutFilters(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	now := time.Now()
 	expectedResponse := entity.ListExchangesResponse{
@@ -109,14 +196,14 @@ func TestExchangesEndpoint_Success_WithoutFilters(t *testing.T) {
 			ID:              1,
 			SourceCurrency:  "USD",
 			TargetCurrency:  "BRL",
-			Rate:            5.25,
+			Rate:            decimal.RequireFromString("5.25"),
 			LastAcquisition: now,
 		},
 		{
 			ID:              2,
 			SourceCurrency:  "EUR",
 			TargetCurrency:  "BRL",
-			Rate:            5.75,
+			Rate:            decimal.RequireFromString("5.75"),
 			LastAcquisition: now,
 		},
 	}
@@ -149,6 +236,173 @@ func TestExchangesEndpoint_Success_WithoutFilters(t *testing.T) {
 	assert.Len(t, response, 2)
 }
 
+func TestExchangesEndpoint_SerializesRateAsString(t *testing.T) {
+	testCases := []struct {
+		name           string
+		decimalNumbers bool
+		accept         string
+		expectedRate   string
+	}{
+		{"decimal string by default", false, "", `"rate":"5.123456789012345"`},
+		{"number for legacy clients", true, "", `"rate":5.123456789012345`},
+		{"number asked for", false, "application/json; decimals=number", `"rate":5.123456789012345`},
+		{"string asked for by a legacy client", true, "text/html, application/json; decimals=string", `"rate":"5.123456789012345"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
+			server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", tc.decimalNumbers).(*echoServer)
+			e := server.newEcho()
+
+			expectedResponse := entity.ListExchangesResponse{
+				{
+					ID:             1,
+					SourceCurrency: "USD",
+					TargetCurrency: "BRL",
+					Rate:           decimal.RequireFromString("5.123456789012345"),
+				},
+			}
+
+			mockUseCase.EXPECT().
+				Execute(gomock.Any(), gomock.Any()).
+				Return(&expectedResponse, nil)
+
+			httpReq := httptest.NewRequest(http.MethodGet, "/exchanges", nil)
+			httpReq.Header.Set(echo.HeaderAccept, tc.accept)
+			rec := httptest.NewRecorder()
+
+			// Act
+			e.ServeHTTP(rec, httpReq)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.expectedRate)
+			assert.Contains(t, rec.Body.String(), `"source_currency":"USD"`)
+		})
+	}
+}
+
+func TestConvertEndpoint_SerializesNestedDecimalsAsNumbers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockConvertUseCase(ctrl)
+	server := NewEchoServer(UseCases{Convert: mockUseCase}, "8080", true).(*echoServer)
+	e := server.newEcho()
+
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		Return(&entity.ConvertResponse{
+			From:   "EUR",
+			To:     "BRL",
+			Amount: decimal.RequireFromString("10"),
+			Result: decimal.RequireFromString("54.50"),
+			Rate:   decimal.RequireFromString("5.45"),
+			Path:   []string{"EUR", "BRL"},
+			Rates:  []entity.ConversionStep{{From: "EUR", To: "BRL", Rate: decimal.RequireFromString("5.45")}},
+		}, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/convert?from=EUR&to=BRL&amount=10", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 10.0, response["amount"])
+	assert.Equal(t, 54.5, response["result"])
+	assert.Equal(t, 5.45, response["rate"])
+	assert.Equal(t, "EUR", response["from"])
+	assert.Equal(t, 5.45, response["rates"].([]any)[0].(map[string]any)["rate"])
+}
+
+func TestExchangesEndpoint_AsOf(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
+
+	ctx := context.Background()
+	asOf := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	req := entity.ListExchangesRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		AsOf:           &asOf,
+	}
+
+	expectedResponse := entity.ListExchangesResponse{
+		{
+			ID:              1,
+			SourceCurrency:  "USD",
+			TargetCurrency:  "BRL",
+			Rate:            decimal.RequireFromString("4.98"),
+			LastAcquisition: asOf.Add(-15 * time.Minute),
+			AsOf:            &asOf,
+		},
+	}
+
+	mockUseCase.EXPECT().
+		Execute(ctx, req).
+		Return(&expectedResponse, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges?source=USD&target=BRL&as_of=2024-03-01T09:00:00-03:00", nil)
+	httpReq = httpReq.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Act
+	err := server.exchangesHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"as_of":"2024-03-01T12:00:00Z"`)
+	assert.Contains(t, rec.Body.String(), `"last_acquisition":"2024-03-01T11:45:00Z"`)
+}
+
+func TestExchangesEndpoint_InvalidAsOf(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges?as_of=last-week", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Act
+	err := server.exchangesHandler(c)
+	httpErrorHandler(err, c)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "/problems/validation-error", response.Type)
+	assert.Equal(t, []entity.FieldError{
+		{Field: "as_of", Message: `"last-week" is not an RFC 3339 timestamp or a YYYY-MM-DD date`},
+	}, response.Errors)
+}
+
 func TestExchangesEndpoint_Error(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -156,7 +410,7 @@ func TestExchangesEndpoint_Error(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	ctx := context.Background()
 	req := entity.ListExchangesRequest{
@@ -176,15 +430,21 @@ func TestExchangesEndpoint_Error(t *testing.T) {
 
 	// Act
 	err := server.exchangesHandler(c)
+	httpErrorHandler(err, c)
 
 	// Assert
-	require.NoError(t, err) // Handler itself doesn't return error, it returns JSON error
+	require.ErrorIs(t, err, expectedError) // Rendered by the central error handler
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
 
-	var response map[string]string
+	var response ErrorResponse
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, "failed to list exchanges", response["error"])
+	assert.Equal(t, "about:blank", response.Type)
+	assert.Equal(t, "Internal Server Error", response.Title)
+	assert.Equal(t, http.StatusInternalServerError, response.Status)
+	assert.Equal(t, "/exchanges?source=USD&target=BRL", response.Instance)
+	assert.NotContains(t, rec.Body.String(), "database connection failed") // Internals never leak
 }
 
 func TestExchangesEndpoint_EmptyResult(t *testing.T) {
@@ -194,7 +454,7 @@ func TestExchangesEndpoint_EmptyResult(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	ctx := context.Background()
 	req := entity.ListExchangesRequest{
@@ -225,6 +485,442 @@ func TestExchangesEndpoint_EmptyResult(t *testing.T) {
 	assert.Len(t, response, 0)
 }
 
+func TestExchangeEndpoint_Success(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockGetExchangeUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{GetExchange: mockUseCase}, "8080", false).(*echoServer)
+
+	ctx := context.Background()
+	req := entity.GetExchangeRequest{
+		SourceCurrency: "usd",
+		TargetCurrency: "brl",
+	}
+
+	mockUseCase.EXPECT().
+		Execute(ctx, req).
+		Return(&entity.ExchangeResponse{
+			ID:             1,
+			SourceCurrency: "USD",
+			TargetCurrency: "BRL",
+			Rate:           decimal.RequireFromString("5.25"),
+		}, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/usd/brl", nil)
+	httpReq = httpReq.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("source", "target")
+	c.SetParamValues("usd", "brl")
+
+	// Act
+	err := server.exchangeHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response entity.ExchangeResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", response.SourceCurrency)
+	assert.Equal(t, "BRL", response.TargetCurrency)
+	assert.Equal(t, "5.25", response.Rate.String())
+}
+
+func TestExchangeEndpoint_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCode   int
+		expectedType   string
+		expectedTitle  string
+		expectedDetail string
+		expectedFields []entity.FieldError
+	}{
+		{
+			name:           "not found",
+			err:            fmt.Errorf("%w: USD-JPY", entity.ErrExchangeNotFound),
+			expectedCode:   http.StatusNotFound,
+			expectedType:   "/problems/exchange-not-found",
+			expectedTitle:  "Exchange not found",
+			expectedDetail: "exchange not found: USD-JPY",
+		},
+		{
+			name: "invalid currencies",
+			err: &entity.ValidationError{Fields: []entity.FieldError{
+				{Field: "source", Message: `"ABC" is not an ISO 4217 currency code`},
+			}},
+			expectedCode:   http.StatusBadRequest,
+			expectedType:   "/problems/validation-error",
+			expectedTitle:  "Invalid request",
+			expectedDetail: `validation failed: source: "ABC" is not an ISO 4217 currency code`,
+			expectedFields: []entity.FieldError{
+				{Field: "source", Message: `"ABC" is not an ISO 4217 currency code`},
+			},
+		},
+		{
+			name:           "unexpected error",
+			err:            errors.New("database connection failed"),
+			expectedCode:   http.StatusInternalServerError,
+			expectedType:   "about:blank",
+			expectedTitle:  "Internal Server Error",
+			expectedDetail: "the server failed to process the request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockGetExchangeUseCase(ctrl)
+			e := echo.New()
+			server := NewEchoServer(UseCases{GetExchange: mockUseCase}, "8080", false).(*echoServer)
+
+			mockUseCase.EXPECT().
+				Execute(gomock.Any(), gomock.Any()).
+				Return(nil, tc.err)
+
+			httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/USD/JPY", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+			c.SetParamNames("source", "target")
+			c.SetParamValues("USD", "JPY")
+
+			// Act
+			err := server.exchangeHandler(c)
+			httpErrorHandler(err, c)
+
+			// Assert
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var response ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, response.Type)
+			assert.Equal(t, tc.expectedTitle, response.Title)
+			assert.Equal(t, tc.expectedCode, response.Status)
+			assert.Equal(t, tc.expectedDetail, response.Detail)
+			assert.Equal(t, "/exchanges/USD/JPY", response.Instance)
+			assert.Equal(t, tc.expectedFields, response.Errors)
+		})
+	}
+}
+
+func TestExchangeHistoryEndpoint_Success(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockExchangeHistoryUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ExchangeHistory: mockUseCase}, "8080", false).(*echoServer)
+
+	ctx := context.Background()
+	req := entity.ExchangeHistoryRequest{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		From:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC),
+		Interval:       entity.HistoryIntervalWeek,
+		Aggregation:    entity.HistoryAggregationAverage,
+	}
+
+	expectedResponse := entity.ExchangeHistoryResponse{
+		SourceCurrency: "USD",
+		TargetCurrency: "BRL",
+		From:           req.From,
+		To:             req.To,
+		Interval:       req.Interval,
+		Aggregation:    req.Aggregation,
+		Points: []entity.ExchangeHistoryPoint{
+			{Time: req.From, Rate: decimal.RequireFromString("5.25"), Samples: 3},
+		},
+	}
+
+	mockUseCase.EXPECT().
+		Execute(ctx, req).
+		Return(&expectedResponse, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/USD/BRL/history?from=2024-01-01&to=2024-01-31T09:00:00-03:00&interval=1w&aggregation=avg", nil)
+	httpReq = httpReq.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("source", "target")
+	c.SetParamValues("USD", "BRL")
+
+	// Act
+	err := server.exchangeHistoryHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response entity.ExchangeHistoryResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, entity.HistoryIntervalWeek, response.Interval)
+	require.Len(t, response.Points, 1)
+	assert.Equal(t, "5.25", response.Points[0].Rate.String())
+	assert.Equal(t, 3, response.Points[0].Samples)
+}
+
+func TestExchangeHistoryEndpoint_Defaults(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockExchangeHistoryUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ExchangeHistory: mockUseCase}, "8080", false).(*echoServer)
+
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req entity.ExchangeHistoryRequest) (*entity.ExchangeHistoryResponse, error) {
+			assert.Equal(t, entity.HistoryIntervalDay, req.Interval)
+			assert.Equal(t, entity.HistoryAggregationLast, req.Aggregation)
+			assert.WithinDuration(t, time.Now(), req.To, time.Minute)
+			assert.Equal(t, defaultHistoryWindow, req.To.Sub(req.From))
+			return &entity.ExchangeHistoryResponse{}, nil
+		})
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/USD/BRL/history", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("source", "target")
+	c.SetParamValues("USD", "BRL")
+
+	// Act
+	err := server.exchangeHistoryHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestExchangeHistoryEndpoint_BadRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedField entity.FieldError
+	}{
+		{"invalid interval", "interval=1m", entity.FieldError{Field: "interval", Message: `invalid interval "1m", expected 1h, 1d or 1w`}},
+		{"invalid aggregation", "aggregation=max", entity.FieldError{Field: "aggregation", Message: `invalid aggregation "max", expected last or avg`}},
+		{"invalid from", "from=yesterday", entity.FieldError{Field: "from", Message: `"yesterday" is not an RFC 3339 timestamp or a YYYY-MM-DD date`}},
+		{"from after to", "from=2024-02-01&to=2024-01-01", entity.FieldError{Field: "from", Message: "must not be after to"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockExchangeHistoryUseCase(ctrl)
+			e := echo.New()
+			server := NewEchoServer(UseCases{ExchangeHistory: mockUseCase}, "8080", false).(*echoServer)
+
+			httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/USD/BRL/history?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+			c.SetParamNames("source", "target")
+			c.SetParamValues("USD", "BRL")
+
+			// Act
+			err := server.exchangeHistoryHandler(c)
+			httpErrorHandler(err, c)
+
+			// Assert
+			require.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, []entity.FieldError{tc.expectedField}, response.Errors)
+		})
+	}
+}
+
+func TestExchangeHistoryEndpoint_Error(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockExchangeHistoryUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{ExchangeHistory: mockUseCase}, "8080", false).(*echoServer)
+
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("database connection failed"))
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/exchanges/USD/BRL/history", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+	c.SetParamNames("source", "target")
+	c.SetParamValues("USD", "BRL")
+
+	// Act
+	err := server.exchangeHistoryHandler(c)
+	httpErrorHandler(err, c)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.Status)
+}
+
+func TestConvertEndpoint_Success(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockConvertUseCase(ctrl)
+	e := echo.New()
+	server := NewEchoServer(UseCases{Convert: mockUseCase}, "8080", false).(*echoServer)
+
+	ctx := context.Background()
+	req := entity.ConvertRequest{
+		From:   "EUR",
+		To:     "BRL",
+		Amount: decimal.RequireFromString("123.45"),
+	}
+
+	expectedResponse := entity.ConvertResponse{
+		From:   "EUR",
+		To:     "BRL",
+		Amount: req.Amount,
+		Result: decimal.RequireFromString("678.98"),
+		Rate:   decimal.RequireFromString("5.5"),
+		Path:   []string{"EUR", "USD", "BRL"},
+		Rates: []entity.ConversionStep{
+			{From: "EUR", To: "USD", Rate: decimal.RequireFromString("1.1")},
+			{From: "USD", To: "BRL", Rate: decimal.RequireFromString("5")},
+		},
+	}
+
+	mockUseCase.EXPECT().
+		Execute(ctx, req).
+		Return(&expectedResponse, nil)
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/convert?from=EUR&to=BRL&amount=123.45", nil)
+	httpReq = httpReq.WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	// Act
+	err := server.convertHandler(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response entity.ConvertResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "678.98", response.Result.String())
+	assert.Equal(t, []string{"EUR", "USD", "BRL"}, response.Path)
+	assert.Len(t, response.Rates, 2)
+}
+
+func TestConvertEndpoint_BadRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedField entity.FieldError
+	}{
+		{"missing amount", "from=USD&to=BRL", entity.FieldError{Field: "amount", Message: "is required"}},
+		{"invalid amount", "from=USD&to=BRL&amount=ten", entity.FieldError{Field: "amount", Message: `"ten" is not a decimal number`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockConvertUseCase(ctrl)
+			e := echo.New()
+			server := NewEchoServer(UseCases{Convert: mockUseCase}, "8080", false).(*echoServer)
+
+			httpReq := httptest.NewRequest(http.MethodGet, "/convert?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+
+			// Act
+			err := server.convertHandler(c)
+			httpErrorHandler(err, c)
+
+			// Assert
+			require.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, []entity.FieldError{tc.expectedField}, response.Errors)
+		})
+	}
+}
+
+func TestConvertEndpoint_UseCaseErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedType string
+	}{
+		{"invalid currency", &entity.ValidationError{Fields: []entity.FieldError{{Field: "from", Message: "is required"}}}, http.StatusBadRequest, "/problems/validation-error"},
+		{"no conversion path", fmt.Errorf("%w: USD-BRL", entity.ErrConversionPathNotFound), http.StatusNotFound, "/problems/conversion-path-not-found"},
+		{"unexpected error", errors.New("database connection failed"), http.StatusInternalServerError, "about:blank"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockConvertUseCase(ctrl)
+			e := echo.New()
+			server := NewEchoServer(UseCases{Convert: mockUseCase}, "8080", false).(*echoServer)
+
+			mockUseCase.EXPECT().
+				Execute(gomock.Any(), gomock.Any()).
+				Return(nil, tc.err)
+
+			httpReq := httptest.NewRequest(http.MethodGet, "/convert?from=USD&to=BRL&amount=1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httpReq, rec)
+
+			// Act
+			err := server.convertHandler(c)
+			httpErrorHandler(err, c)
+
+			// Assert
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			var response ErrorResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedType, response.Type)
+			assert.Equal(t, tc.expectedCode, response.Status)
+		})
+	}
+}
+
 func TestOpenAPIEndpoint_Success(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -268,6 +964,9 @@ func TestOpenAPIEndpoint_Success(t *testing.T) {
 	paths := response["paths"].(map[string]interface{})
 	assert.Contains(t, paths, "/status")
 	assert.Contains(t, paths, "/exchanges")
+	assert.Contains(t, paths, "/exchanges/{source}/{target}")
+	assert.Contains(t, paths, "/exchanges/{source}/{target}/history")
+	assert.Contains(t, paths, "/convert")
 	assert.Contains(t, paths, "/openapi.json")
 
 	_ = mockUseCase // Avoid unused variable warning
@@ -354,7 +1053,7 @@ func TestDocsEndpoint_Success(t *testing.T) {
 
 	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
 	e := echo.New()
-	server := NewEchoServer(mockUseCase, "8080").(*echoServer)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
 
 	req := httptest.NewRequest(http.MethodGet, "/docs", nil)
 	rec := httptest.NewRecorder()
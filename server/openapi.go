@@ -3,25 +3,60 @@ package server
 import (
 	"net/http"
 
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/clients/exchangerate"
 	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/scheduler"
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
 // StatusResponse represents the health check response
 type StatusResponse struct {
-	Status string `json:"status" example:"ok"`
+	Status    string                       `json:"status" example:"ok"`
+	Providers []exchangerate.BreakerStatus `json:"providers,omitempty" description:"Circuit breakers of the exchange rate providers, when the sync worker runs in this process"`
+	Sync      *scheduler.Projection        `json:"sync,omitempty" description:"Monthly call budget projection of the sync worker, when it runs in this process"`
 }
 
 // ListExchangesQueryParams represents query parameters for listing exchanges
 type ListExchangesQueryParams struct {
 	Source string `query:"source" description:"Source currency code (e.g., USD, EUR)" example:"USD"`
 	Target string `query:"target" description:"Target currency code (e.g., BRL, EUR)" example:"BRL"`
+	AsOf   string `query:"as_of" description:"Return the rates in effect at this moment, as an RFC 3339 timestamp or a YYYY-MM-DD date, instead of the latest ones" example:"2024-03-01T12:00:00Z"`
 }
 
-// ErrorResponse represents an error response
+// ExchangeHistoryParams represents path and query parameters for an exchange's history
+type ExchangeHistoryParams struct {
+	Source      string `path:"source" description:"Source currency code (e.g., USD, EUR)" example:"USD"`
+	Target      string `path:"target" description:"Target currency code (e.g., BRL, EUR)" example:"BRL"`
+	From        string `query:"from" description:"Start of the range, inclusive, as an RFC 3339 timestamp or a YYYY-MM-DD date. Defaults to 30 days before to" example:"2024-01-01"`
+	To          string `query:"to" description:"End of the range, inclusive, as an RFC 3339 timestamp or a YYYY-MM-DD date. Defaults to now" example:"2024-01-31T00:00:00Z"`
+	Interval    string `query:"interval" description:"Bucket width, aligned in UTC. Weeks start on Monday" enum:"1h,1d,1w" default:"1d"`
+	Aggregation string `query:"aggregation" description:"Value of each bucket: its last rate or the average of its rates" enum:"last,avg" default:"last"`
+}
+
+// ConvertQueryParams represents query parameters for converting an amount
+type ConvertQueryParams struct {
+	From   string `query:"from" required:"true" description:"Currency code to convert from (e.g., EUR)" example:"EUR"`
+	To     string `query:"to" required:"true" description:"Currency code to convert to (e.g., BRL)" example:"BRL"`
+	Amount string `query:"amount" required:"true" format:"decimal" description:"Amount to convert" example:"123.45"`
+}
+
+// ErrorResponse represents an RFC 7807 problem details response
 type ErrorResponse struct {
-	Error string `json:"error" example:"failed to list exchanges"`
+	Type      string              `json:"type" description:"URI reference identifying the problem type" example:"/problems/exchange-not-found"`
+	Title     string              `json:"title" description:"Short summary of the problem type" example:"Exchange not found"`
+	Status    int                 `json:"status" description:"HTTP status code" example:"404"`
+	Detail    string              `json:"detail,omitempty" description:"Explanation of this occurrence of the problem" example:"exchange not found: USD-JPY"`
+	Instance  string              `json:"instance,omitempty" description:"Request URI the problem occurred on" example:"/exchanges/USD/JPY"`
+	RequestID string              `json:"request_id,omitempty" description:"Identifier of the request, also sent in the X-Request-Id header" example:"4CZJmYk5Z9V8n0Q3bX2DqjdN8Z0Kz0Tb"`
+	Errors    []entity.FieldError `json:"errors,omitempty" description:"Invalid request fields, on validation problems"`
+}
+
+// ExchangeParams represents path and query parameters for a single exchange
+type ExchangeParams struct {
+	Source string `path:"source" description:"Source ISO 4217 currency code, case-insensitive (e.g., USD, EUR)" example:"USD"`
+	Target string `path:"target" description:"Target ISO 4217 currency code, case-insensitive (e.g., BRL, EUR)" example:"BRL"`
+	AsOf   string `query:"as_of" description:"Return the rate in effect at this moment, as an RFC 3339 timestamp or a YYYY-MM-DD date, instead of the latest one" example:"2024-03-01T12:00:00Z"`
 }
 
 // GenerateOpenAPISpec creates the OpenAPI 3.0 specification for the API
@@ -63,11 +98,12 @@ func GenerateOpenAPISpec(serverURL string) (*openapi3.Spec, error) {
 		return nil, err
 	}
 	statusOp.SetSummary("Health check")
-	statusOp.SetDescription("Returns the health status of the service")
+	statusOp.SetDescription("Returns the health status of the service, the state of its exchange rate providers and when the next sync runs within the monthly call budget")
 	statusOp.SetTags("Health")
 	statusOp.AddRespStructure(new(StatusResponse), func(cu *openapi.ContentUnit) {
 		cu.HTTPStatus = http.StatusOK
 	})
+	addProblemResponses(statusOp, http.StatusInternalServerError)
 	if err := reflector.AddOperation(statusOp); err != nil {
 		return nil, err
 	}
@@ -78,19 +114,68 @@ func GenerateOpenAPISpec(serverURL string) (*openapi3.Spec, error) {
 		return nil, err
 	}
 	exchangesOp.SetSummary("List exchange rates")
-	exchangesOp.SetDescription("Retrieves a list of exchange rates, optionally filtered by source and/or target currency")
+	exchangesOp.SetDescription("Retrieves a list of exchange rates, optionally filtered by source and/or target currency. With as_of, each rate is the latest one recorded at or before that moment and last_acquisition tells when it was observed")
 	exchangesOp.SetTags("Exchanges")
 	exchangesOp.AddReqStructure(new(ListExchangesQueryParams))
 	exchangesOp.AddRespStructure(new(entity.ListExchangesResponse), func(cu *openapi.ContentUnit) {
 		cu.HTTPStatus = http.StatusOK
 	})
-	exchangesOp.AddRespStructure(new(ErrorResponse), func(cu *openapi.ContentUnit) {
-		cu.HTTPStatus = http.StatusInternalServerError
-	})
+	addProblemResponses(exchangesOp, http.StatusBadRequest, http.StatusInternalServerError)
 	if err := reflector.AddOperation(exchangesOp); err != nil {
 		return nil, err
 	}
 
+	// GET /exchanges/{source}/{target} endpoint
+	exchangeOp, err := reflector.NewOperationContext(http.MethodGet, "/exchanges/{source}/{target}")
+	if err != nil {
+		return nil, err
+	}
+	exchangeOp.SetSummary("Get an exchange rate")
+	exchangeOp.SetDescription("Retrieves the exchange rate of a single currency pair. Currency codes are validated against ISO 4217")
+	exchangeOp.SetTags("Exchanges")
+	exchangeOp.AddReqStructure(new(ExchangeParams))
+	exchangeOp.AddRespStructure(new(entity.ExchangeResponse), func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+	})
+	addProblemResponses(exchangeOp, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	if err := reflector.AddOperation(exchangeOp); err != nil {
+		return nil, err
+	}
+
+	// GET /exchanges/{source}/{target}/history endpoint
+	historyOp, err := reflector.NewOperationContext(http.MethodGet, "/exchanges/{source}/{target}/history")
+	if err != nil {
+		return nil, err
+	}
+	historyOp.SetSummary("Exchange rate history")
+	historyOp.SetDescription("Retrieves the recorded rates of an exchange within a time range, grouped into hourly, daily or weekly buckets")
+	historyOp.SetTags("Exchanges")
+	historyOp.AddReqStructure(new(ExchangeHistoryParams))
+	historyOp.AddRespStructure(new(entity.ExchangeHistoryResponse), func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+	})
+	addProblemResponses(historyOp, http.StatusBadRequest, http.StatusInternalServerError)
+	if err := reflector.AddOperation(historyOp); err != nil {
+		return nil, err
+	}
+
+	// GET /convert endpoint
+	convertOp, err := reflector.NewOperationContext(http.MethodGet, "/convert")
+	if err != nil {
+		return nil, err
+	}
+	convertOp.SetSummary("Convert an amount")
+	convertOp.SetDescription("Converts an amount using the direct exchange rate, the inverse of the opposite pair or, failing both, a pivot currency. The result is rounded to the target currency's ISO 4217 minor units")
+	convertOp.SetTags("Exchanges")
+	convertOp.AddReqStructure(new(ConvertQueryParams))
+	convertOp.AddRespStructure(new(entity.ConvertResponse), func(cu *openapi.ContentUnit) {
+		cu.HTTPStatus = http.StatusOK
+	})
+	addProblemResponses(convertOp, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	if err := reflector.AddOperation(convertOp); err != nil {
+		return nil, err
+	}
+
 	// GET /openapi.json endpoint (self-documenting)
 	openAPIOp, err := reflector.NewOperationContext(http.MethodGet, "/openapi.json")
 	if err != nil {
@@ -103,6 +188,7 @@ func GenerateOpenAPISpec(serverURL string) (*openapi3.Spec, error) {
 		cu.HTTPStatus = http.StatusOK
 		cu.Description = "OpenAPI specification"
 	})
+	addProblemResponses(openAPIOp, http.StatusInternalServerError)
 	if err := reflector.AddOperation(openAPIOp); err != nil {
 		return nil, err
 	}
@@ -110,6 +196,16 @@ func GenerateOpenAPISpec(serverURL string) (*openapi3.Spec, error) {
 	return reflector.Spec, nil
 }
 
+// addProblemResponses documents the problem details an operation may respond with.
+func addProblemResponses(op openapi.OperationContext, statuses ...int) {
+	for _, status := range statuses {
+		op.AddRespStructure(new(ErrorResponse), func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = status
+			cu.ContentType = MIMEApplicationProblemJSON
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// problemTypeBlank is the RFC 7807 type of problems with no more semantics than their status.
+const problemTypeBlank = "about:blank"
+
+// domainProblems maps sentinel domain errors to their problem type and status.
+var domainProblems = []struct {
+	err    error
+	status int
+	typ    string
+	title  string
+}{
+	{entity.ErrExchangeNotFound, http.StatusNotFound, "/problems/exchange-not-found", "Exchange not found"},
+	{entity.ErrConversionPathNotFound, http.StatusNotFound, "/problems/conversion-path-not-found", "Conversion path not found"},
+}
+
+// httpErrorHandler renders every error returned by a handler as problem details,
+// mapping domain errors to their status. Unexpected errors are logged and their
+// message is never sent to clients.
+func httpErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := problemFor(err)
+	problem.Instance = c.Request().URL.RequestURI()
+	problem.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	if problem.Status >= http.StatusInternalServerError {
+		log.Error().Err(err).Str("request_id", problem.RequestID).Msgf("%s %s failed", c.Request().Method, problem.Instance)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		err = c.JSON(problem.Status, problem)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("failed to write problem response")
+	}
+}
+
+func problemFor(err error) ErrorResponse {
+	var validationErr *entity.ValidationError
+	if errors.As(err, &validationErr) {
+		return ErrorResponse{
+			Type:   "/problems/validation-error",
+			Title:  "Invalid request",
+			Status: http.StatusBadRequest,
+			Detail: validationErr.Error(),
+			Errors: validationErr.Fields,
+		}
+	}
+
+	for _, domainProblem := range domainProblems {
+		if errors.Is(err, domainProblem.err) {
+			return ErrorResponse{
+				Type:   domainProblem.typ,
+				Title:  domainProblem.title,
+				Status: domainProblem.status,
+				Detail: err.Error(),
+			}
+		}
+	}
+
+	// Echo's own 4xx errors, like unknown routes. Its 5xx errors fall through
+	// to the generic problem below, as do recovered panics.
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+		problem := ErrorResponse{
+			Type:   problemTypeBlank,
+			Title:  http.StatusText(httpErr.Code),
+			Status: httpErr.Code,
+		}
+		if message, ok := httpErr.Message.(string); ok && message != problem.Title {
+			problem.Detail = message
+		}
+		return problem
+	}
+
+	return ErrorResponse{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+		Detail: "the server failed to process the request",
+	}
+}
+
+// invalidField reports a single invalid request field as a validation error.
+func invalidField(field, message string) error {
+	var validation entity.ValidationError
+	validation.Add(field, message)
+	return &validation
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity"
+	"github.com/jorgejr568/exchange-register-go/internal/exchange/entity/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHTTPErrorHandler_UnknownRoute(t *testing.T) {
+	// Arrange
+	server := NewEchoServer(UseCases{}, "8080", false).(*echoServer)
+	e := server.newEcho()
+
+	req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var response ErrorResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "about:blank", response.Type)
+	assert.Equal(t, "Not Found", response.Title)
+	assert.Equal(t, http.StatusNotFound, response.Status)
+	assert.Empty(t, response.Detail)
+	assert.Equal(t, "/unknown", response.Instance)
+	assert.NotEmpty(t, response.RequestID)
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), response.RequestID)
+}
+
+func TestHTTPErrorHandler_DomainError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockGetExchangeUseCase(ctrl)
+	server := NewEchoServer(UseCases{GetExchange: mockUseCase}, "8080", false).(*echoServer)
+	e := server.newEcho()
+
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), entity.GetExchangeRequest{SourceCurrency: "usd", TargetCurrency: "jpy"}).
+		Return(nil, fmt.Errorf("%w: USD-JPY", entity.ErrExchangeNotFound))
+
+	req := httptest.NewRequest(http.MethodGet, "/exchanges/usd/jpy", nil)
+	req.Header.Set(echo.HeaderXRequestID, "request-123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var response ErrorResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, ErrorResponse{
+		Type:      "/problems/exchange-not-found",
+		Title:     "Exchange not found",
+		Status:    http.StatusNotFound,
+		Detail:    "exchange not found: USD-JPY",
+		Instance:  "/exchanges/usd/jpy",
+		RequestID: "request-123",
+	}, response)
+}
+
+func TestHTTPErrorHandler_RecoveredPanic(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockListExchangesUseCase(ctrl)
+	server := NewEchoServer(UseCases{ListExchanges: mockUseCase}, "8080", false).(*echoServer)
+	e := server.newEcho()
+
+	mockUseCase.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req entity.ListExchangesRequest) (*entity.ListExchangesResponse, error) {
+			panic("unexpected nil pointer")
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/exchanges", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.NotContains(t, rec.Body.String(), "nil pointer")
+}
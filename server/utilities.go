@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -34,3 +35,21 @@ func getServerURL(c echo.Context) string {
 
 	return fmt.Sprintf("%s://%s", scheme, host)
 }
+
+// parseTimeParam parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC),
+// returning fallback when the value is empty.
+func parseTimeParam(name, value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, invalidField(name, fmt.Sprintf("%q is not an RFC 3339 timestamp or a YYYY-MM-DD date", value))
+}